@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/services"
+)
+
+// commandServices are the services available to command-line subcommands
+type commandServices struct {
+	questionBank *services.QuestionBankService
+	seeder       *services.DatabaseSeeder
+}
+
+// runCommand runs a command-line subcommand instead of the server
+func runCommand(ctx context.Context, args []string, deps commandServices) error {
+	switch args[0] {
+	case "export-questions":
+		return exportQuestions(ctx, args[1:], deps.questionBank)
+	case "import-questions":
+		return importQuestions(ctx, args[1:], deps.questionBank)
+	case "seed-questions":
+		return seedQuestions(ctx, args[1:], deps.seeder)
+	}
+	return fmt.Errorf("unknown command %q, available: export-questions, import-questions, seed-questions", args[0])
+}
+
+// exportQuestions writes the question bank to a file or standard output:
+//
+//	export-questions [-format csv|json|yaml] [-out questions.csv]
+func exportQuestions(ctx context.Context, args []string, bankService *services.QuestionBankService) error {
+	flags := flag.NewFlagSet("export-questions", flag.ContinueOnError)
+	format := flags.String("format", "", "csv, json or yaml; defaults to the extension of -out, else json")
+	out := flags.String("out", "", "file to write instead of standard output")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *format == "" {
+		*format = formatFromExtension(*out)
+	}
+	if !models.IsValidQuestionBankFormat(*format) {
+		return fmt.Errorf("format must be csv, json or yaml")
+	}
+
+	data, err := bankService.Export(ctx, *format)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(*out, data, 0o644)
+}
+
+// importQuestions upserts the questions of a file and prints the import report:
+//
+//	import-questions [-format csv|json|yaml] [-dry-run] [-on-error fail|skip] questions.csv
+func importQuestions(ctx context.Context, args []string, bankService *services.QuestionBankService) error {
+	flags := flag.NewFlagSet("import-questions", flag.ContinueOnError)
+	format := flags.String("format", "", "csv, json or yaml; defaults to the file extension")
+	dryRun := flags.Bool("dry-run", false, "report what would change without changing anything")
+	onError := flags.String("on-error", "fail", "fail rejects a file with invalid rows, skip imports the valid ones")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: import-questions [flags] file")
+	}
+	if *onError != "fail" && *onError != "skip" {
+		return fmt.Errorf("on-error must be fail or skip")
+	}
+
+	path := flags.Arg(0)
+	if *format == "" {
+		*format = formatFromExtension(path)
+	}
+	if !models.IsValidQuestionBankFormat(*format) {
+		return fmt.Errorf("format must be csv, json or yaml")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	report, err := bankService.Import(ctx, data, models.ImportOptions{
+		Format:      *format,
+		DryRun:      *dryRun,
+		SkipInvalid: *onError == "skip",
+	})
+	if err != nil && !errors.Is(err, services.ErrQuestionBankRejected) {
+		return err
+	}
+
+	output, marshalErr := json.MarshalIndent(report, "", "  ")
+	if marshalErr != nil {
+		return marshalErr
+	}
+	fmt.Println(string(output))
+	return err
+}
+
+// seedQuestions applies the seed packs and prints what each one did:
+//
+//	seed-questions [-force]
+func seedQuestions(ctx context.Context, args []string, seeder *services.DatabaseSeeder) error {
+	flags := flag.NewFlagSet("seed-questions", flag.ContinueOnError)
+	force := flags.Bool("force", false, "re-apply every pack and overwrite questions edited since they were seeded")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	results, err := seeder.SeedQuestions(ctx, *force)
+	if err != nil {
+		return err
+	}
+
+	output, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
+// formatFromExtension returns the question bank format matching a file name, defaulting to JSON
+func formatFromExtension(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return models.QuestionBankCSV
+	case ".yaml", ".yml":
+		return models.QuestionBankYAML
+	}
+	return models.QuestionBankJSON
+}
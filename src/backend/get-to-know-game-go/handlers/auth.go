@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	errMissingToken = errors.New("missing access token")
+	errInvalidToken = errors.New("invalid access token")
+)
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header
+func bearerToken(c *fiber.Ctx) string {
+	header := c.Get(fiber.HeaderAuthorization)
+	if len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+		return strings.TrimSpace(header[7:])
+	}
+	return ""
+}
+
+// authenticateSessionPlayer resolves which player of the session the request's token belongs to
+func authenticateSessionPlayer(c *fiber.Ctx, tokenService *services.TokenService, session models.GameSession) (primitive.ObjectID, error) {
+	token := bearerToken(c)
+	if token == "" {
+		return primitive.NilObjectID, errMissingToken
+	}
+
+	if tokenService.Matches(session.Player1TokenHash, token) {
+		return session.Player1ID, nil
+	}
+	if session.Player2ID != nil && tokenService.Matches(session.Player2TokenHash, token) {
+		return *session.Player2ID, nil
+	}
+
+	return primitive.NilObjectID, errInvalidToken
+}
+
+// authErrorResponse maps authentication errors to 401 or 403 responses
+func authErrorResponse(c *fiber.Ctx, err error) error {
+	if errors.Is(err, errMissingToken) {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Access token required"})
+	}
+	return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Invalid access token"})
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BroadcastsHandler handles broadcast-related HTTP requests
+type BroadcastsHandler struct {
+	broadcastService *services.BroadcastService
+	tokenService     *services.TokenService
+}
+
+// NewBroadcastsHandler creates a new broadcasts handler
+func NewBroadcastsHandler(broadcastService *services.BroadcastService, tokenService *services.TokenService) *BroadcastsHandler {
+	return &BroadcastsHandler{
+		broadcastService: broadcastService,
+		tokenService:     tokenService,
+	}
+}
+
+// CreateBroadcast handles POST /api/broadcasts
+func (h *BroadcastsHandler) CreateBroadcast(c *fiber.Ctx) error {
+	var req models.CreateBroadcastRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	broadcast, token, err := h.broadcastService.CreateBroadcast(c.Context(), req)
+	if err == services.ErrInvalidAudience {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":     "Invalid audience",
+			"audiences": models.AllRatings(),
+		})
+	}
+	if err == services.ErrInvalidShuffleMode {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":        "Invalid shuffle mode",
+			"shuffleModes": models.AllShuffleModes(),
+		})
+	}
+	if err == services.ErrNoMatchingQuestions {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "No questions match the audience and tags"})
+	}
+	if err == services.ErrPackNotPublished {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Question pack has not been published"})
+	}
+	if err == services.ErrPackNotFound {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Question pack not found"})
+	}
+	if err != nil {
+		return validationErrorResponse(c, err, "Failed to create broadcast")
+	}
+
+	invites := make([]fiber.Map, 0, len(broadcast.Invites))
+	for _, invite := range broadcast.Invites {
+		invites = append(invites, fiber.Map{
+			"partnerName": invite.PartnerName,
+			"sessionId":   invite.SessionID.Hex(),
+			"joinCode":    invite.JoinCode,
+			"link":        "/session/" + invite.SessionID.Hex(),
+		})
+	}
+
+	response := fiber.Map{
+		"broadcastId":  broadcast.ID.Hex(),
+		"player1Id":    broadcast.Player1ID.Hex(),
+		"player1Token": token,
+		"invites":      invites,
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// SubmitBroadcastAnswers handles PUT /api/broadcasts/:broadcastId/answers
+func (h *BroadcastsHandler) SubmitBroadcastAnswers(c *fiber.Ctx) error {
+	var req models.SubmitBroadcastAnswersRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	broadcast, err := h.broadcastService.GetBroadcast(c.Context(), c.Params("broadcastId"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Broadcast not found"})
+	}
+
+	if err := h.authorizeBroadcast(c, broadcast); err != nil {
+		return authErrorResponse(c, err)
+	}
+
+	err = h.broadcastService.SubmitAnswers(c.Context(), broadcast, req.Answers)
+	if err == services.ErrSessionScored {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Answers can't be changed once the results are in"})
+	}
+	if err != nil {
+		return validationErrorResponse(c, err, "Failed to submit answers")
+	}
+
+	return c.JSON(fiber.Map{"message": "Answers submitted successfully"})
+}
+
+// GetComparison handles GET /api/broadcasts/:broadcastId/comparison
+func (h *BroadcastsHandler) GetComparison(c *fiber.Ctx) error {
+	broadcast, err := h.broadcastService.GetBroadcast(c.Context(), c.Params("broadcastId"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Broadcast not found"})
+	}
+
+	if err := h.authorizeBroadcast(c, broadcast); err != nil {
+		return authErrorResponse(c, err)
+	}
+
+	partners, err := h.broadcastService.Compare(c.Context(), broadcast)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to compare partners"})
+	}
+
+	return c.JSON(fiber.Map{
+		"broadcastId": broadcast.ID.Hex(),
+		"partners":    partners,
+	})
+}
+
+// authorizeBroadcast checks that the request carries player 1's token
+func (h *BroadcastsHandler) authorizeBroadcast(c *fiber.Ctx, broadcast models.Broadcast) error {
+	token := bearerToken(c)
+	if token == "" {
+		return errMissingToken
+	}
+	if !h.tokenService.Matches(broadcast.Player1TokenHash, token) {
+		return errInvalidToken
+	}
+	return nil
+}
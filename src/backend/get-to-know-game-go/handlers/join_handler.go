@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"strings"
+
+	"get-to-know-game-go/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// JoinHandler handles join code HTTP requests
+type JoinHandler struct {
+	joinCodeService *services.JoinCodeService
+}
+
+// NewJoinHandler creates a new join handler
+func NewJoinHandler(joinCodeService *services.JoinCodeService) *JoinHandler {
+	return &JoinHandler{
+		joinCodeService: joinCodeService,
+	}
+}
+
+// ResolveJoinCode handles GET /api/join/:code
+func (h *JoinHandler) ResolveJoinCode(c *fiber.Ctx) error {
+	joinCode, err := h.joinCodeService.Resolve(c.Context(), c.Params("code"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Join code not found or expired"})
+	}
+
+	if !joinCode.RoomID.IsZero() {
+		return c.JSON(fiber.Map{
+			"roomId": joinCode.RoomID.Hex(),
+			"link":   "/room/" + joinCode.RoomID.Hex(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"sessionId": joinCode.SessionID.Hex(),
+		"link":      "/session/" + joinCode.SessionID.Hex(),
+	})
+}
+
+// ClientIP identifies the client of a request for rate limiting. Behind a trusted proxy it is the
+// last address of the proxy header, the one the proxy added itself, so clients can't choose their
+// own key by sending the header.
+func ClientIP(c *fiber.Ctx) string {
+	ip := c.IP()
+	if i := strings.LastIndex(ip, ","); i >= 0 {
+		ip = ip[i+1:]
+	}
+	return strings.TrimSpace(ip)
+}
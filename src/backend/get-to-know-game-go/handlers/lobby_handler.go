@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// maxLobbyWait caps how long a long-polling ticket request is held open
+	maxLobbyWait = 25 * time.Second
+	// lobbyPollInterval is how often a held request re-checks for a partner
+	lobbyPollInterval = time.Second
+)
+
+var errTicketNotFound = errors.New("lobby ticket not found")
+
+// LobbyHandler handles matchmaking lobby HTTP requests
+type LobbyHandler struct {
+	lobbyService *services.LobbyService
+	tokenService *services.TokenService
+}
+
+// NewLobbyHandler creates a new lobby handler
+func NewLobbyHandler(lobbyService *services.LobbyService, tokenService *services.TokenService) *LobbyHandler {
+	return &LobbyHandler{
+		lobbyService: lobbyService,
+		tokenService: tokenService,
+	}
+}
+
+// JoinLobby handles POST /api/lobby/join
+func (h *LobbyHandler) JoinLobby(c *fiber.Ctx) error {
+	var req models.JoinLobbyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	req.PlayerName = strings.TrimSpace(req.PlayerName)
+	if req.PlayerName == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Player name is required"})
+	}
+
+	ticket, token, err := h.lobbyService.Join(c.Context(), req, ClientIP(c))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to join lobby"})
+	}
+
+	response := lobbyTicketResponse(ticket)
+	response["token"] = token
+
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// GetTicket handles GET /api/lobby/:ticketId
+// Clients poll this endpoint; with ?wait=<seconds> the request is held open until
+// the player is matched or the wait runs out.
+func (h *LobbyHandler) GetTicket(c *fiber.Ctx) error {
+	ticket, err := h.authorizedTicket(c)
+	if err != nil {
+		return ticketErrorResponse(c, err)
+	}
+
+	wait := time.Duration(c.QueryInt("wait", 0)) * time.Second
+	if wait > maxLobbyWait {
+		wait = maxLobbyWait
+	}
+	deadline := time.Now().Add(wait)
+
+	for {
+		ticket, err = h.lobbyService.Refresh(c.Context(), ticket)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to refresh lobby ticket"})
+		}
+
+		if ticket.Status != models.LobbyStatusWaiting || !time.Now().Add(lobbyPollInterval).Before(deadline) {
+			break
+		}
+		time.Sleep(lobbyPollInterval)
+	}
+
+	return c.JSON(lobbyTicketResponse(ticket))
+}
+
+// CancelTicket handles DELETE /api/lobby/:ticketId
+func (h *LobbyHandler) CancelTicket(c *fiber.Ctx) error {
+	ticket, err := h.authorizedTicket(c)
+	if err != nil {
+		return ticketErrorResponse(c, err)
+	}
+
+	err = h.lobbyService.Cancel(c.Context(), ticket)
+	if err != nil {
+		if errors.Is(err, services.ErrLobbyTicketFinished) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Lobby ticket is no longer waiting"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to cancel lobby ticket"})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// authorizedTicket loads the ticket from the route and checks the caller's token
+func (h *LobbyHandler) authorizedTicket(c *fiber.Ctx) (models.LobbyTicket, error) {
+	ticket, err := h.lobbyService.GetTicket(c.Context(), c.Params("ticketId"))
+	if err != nil {
+		return ticket, errTicketNotFound
+	}
+
+	token := bearerToken(c)
+	if token == "" {
+		return ticket, errMissingToken
+	}
+	if !h.tokenService.Matches(ticket.TokenHash, token) {
+		return ticket, errInvalidToken
+	}
+
+	return ticket, nil
+}
+
+// ticketErrorResponse maps ticket lookup and authentication errors to responses
+func ticketErrorResponse(c *fiber.Ctx, err error) error {
+	if errors.Is(err, errTicketNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Lobby ticket not found"})
+	}
+	return authErrorResponse(c, err)
+}
+
+// lobbyTicketResponse builds the client view of a lobby ticket
+func lobbyTicketResponse(ticket models.LobbyTicket) fiber.Map {
+	response := fiber.Map{
+		"ticketId":  ticket.ID.Hex(),
+		"status":    ticket.Status,
+		"expiresAt": ticket.ExpiresAt,
+	}
+
+	if ticket.Status == models.LobbyStatusMatched && ticket.SessionID != nil && ticket.PlayerID != nil {
+		response["sessionId"] = ticket.SessionID.Hex()
+		response["playerId"] = ticket.PlayerID.Hex()
+		response["playerNumber"] = ticket.PlayerNumber
+		response["link"] = "/session/" + ticket.SessionID.Hex()
+	}
+
+	return response
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"encoding/json"
+	"strings"
+
+	"get-to-know-game-go/i18n"
+	"get-to-know-game-go/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// languageLocal is the fiber.Ctx local holding the negotiated language of a request
+const languageLocal = "language"
+
+// Localize negotiates the language of each request from ?lang= or Accept-Language, falling back
+// to defaultLanguage, and translates the "error" and "message" fields of JSON responses into it
+func Localize(defaultLanguage string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		language := i18n.Negotiate(c.Query("lang"), c.Get(fiber.HeaderAcceptLanguage), defaultLanguage)
+		c.Locals(languageLocal, language)
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		c.Set(fiber.HeaderContentLanguage, language)
+		c.Append(fiber.HeaderVary, fiber.HeaderAcceptLanguage)
+		if language == i18n.English || !strings.HasPrefix(string(c.Response().Header.ContentType()), fiber.MIMEApplicationJSON) {
+			return nil
+		}
+
+		// Messages are written in English; only plain objects carry translatable messages
+		var body map[string]interface{}
+		if err := json.Unmarshal(c.Response().Body(), &body); err != nil {
+			return nil
+		}
+		translated := false
+		for _, field := range []string{"error", "message"} {
+			if message, ok := body[field].(string); ok {
+				body[field] = i18n.Translate(language, message)
+				translated = true
+			}
+		}
+		if !translated {
+			return nil
+		}
+
+		localized, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		c.Response().SetBodyRaw(localized)
+		return nil
+	}
+}
+
+// language returns the negotiated language of the request
+func language(c *fiber.Ctx) string {
+	if language, ok := c.Locals(languageLocal).(string); ok {
+		return language
+	}
+	return i18n.English
+}
+
+// requestLocalizer returns a localizer for the language of the request
+func requestLocalizer(c *fiber.Ctx, localizationService *services.LocalizationService) (*services.Localizer, error) {
+	return localizationService.Localizer(c.Context(), language(c))
+}
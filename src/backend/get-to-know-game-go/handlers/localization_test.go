@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestLocalizeTranslatesOnlyMessages(t *testing.T) {
+	app := fiber.New()
+	app.Use(Localize("en"))
+	app.Get("/error", func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Session not found", "name": "Session not found"})
+	})
+	app.Get("/message", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"message": "Answers submitted successfully", "section": "Session not found"})
+	})
+	app.Get("/list", func(c *fiber.Ctx) error {
+		return c.JSON([]string{"Session not found"})
+	})
+	app.Get("/text", func(c *fiber.Ctx) error {
+		return c.SendString("Session not found")
+	})
+
+	tests := []struct {
+		name           string
+		path           string
+		acceptLanguage string
+		want           string
+		language       string
+	}{
+		{
+			name:           "error translated",
+			path:           "/error",
+			acceptLanguage: "ro-RO",
+			want:           `{"error":"Sesiunea nu a fost găsită","name":"Session not found"}`,
+			language:       "ro",
+		},
+		{
+			name:     "query language",
+			path:     "/error?lang=es",
+			want:     `{"error":"Sesión no encontrada","name":"Session not found"}`,
+			language: "es",
+		},
+		{
+			name:           "unknown language left in english",
+			path:           "/error",
+			acceptLanguage: "fr",
+			want:           `{"error":"Session not found","name":"Session not found"}`,
+			language:       "en",
+		},
+		{
+			name:           "message translated, other fields left alone",
+			path:           "/message",
+			acceptLanguage: "es",
+			want:           `{"message":"Respuestas enviadas correctamente","section":"Session not found"}`,
+			language:       "es",
+		},
+		{
+			name:           "arrays left alone",
+			path:           "/list",
+			acceptLanguage: "es",
+			want:           `["Session not found"]`,
+			language:       "es",
+		},
+		{
+			name:           "text left alone",
+			path:           "/text",
+			acceptLanguage: "es",
+			want:           "Session not found",
+			language:       "es",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, tt.path, nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set(fiber.HeaderAcceptLanguage, tt.acceptLanguage)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request: %v", err)
+			}
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("read body: %v", err)
+			}
+
+			if got := resp.Header.Get(fiber.HeaderContentLanguage); got != tt.language {
+				t.Errorf("Content-Language = %q, want %q", got, tt.language)
+			}
+			if string(body) != tt.want {
+				t.Errorf("body = %s, want %s", body, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PacksHandler handles question pack HTTP requests
+type PacksHandler struct {
+	packService *services.PackService
+}
+
+// NewPacksHandler creates a new packs handler
+func NewPacksHandler(packService *services.PackService) *PacksHandler {
+	return &PacksHandler{
+		packService: packService,
+	}
+}
+
+// GetPacks handles GET /api/packs
+func (h *PacksHandler) GetPacks(c *fiber.Ctx) error {
+	packs, err := h.packService.ListPacks(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch question packs"})
+	}
+
+	return c.JSON(packs)
+}
+
+// GetPack handles GET /api/packs/:packId, where the pack is given by ID or key
+func (h *PacksHandler) GetPack(c *fiber.Ctx) error {
+	pack, err := h.packService.GetPack(c.Context(), c.Params("packId"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Question pack not found"})
+	}
+
+	return c.JSON(pack)
+}
+
+// CreatePack handles POST /api/packs
+func (h *PacksHandler) CreatePack(c *fiber.Ctx) error {
+	var req models.CreatePackRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	pack, err := h.packService.CreatePack(c.Context(), req)
+	if err == services.ErrPackExists {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "A question pack with this name already exists"})
+	}
+	if err != nil {
+		return validationErrorResponse(c, err, "Failed to create question pack")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(pack)
+}
+
+// UpdatePack handles PUT /api/packs/:packId
+func (h *PacksHandler) UpdatePack(c *fiber.Ctx) error {
+	var req models.UpdatePackRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	pack, err := h.packService.UpdatePack(c.Context(), c.Params("packId"), req)
+	if err == services.ErrPackNotFound {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Question pack not found"})
+	}
+	if err != nil {
+		return validationErrorResponse(c, err, "Failed to update question pack")
+	}
+
+	return c.JSON(pack)
+}
+
+// PreviewPack handles GET /api/packs/:packId/preview
+func (h *PacksHandler) PreviewPack(c *fiber.Ctx) error {
+	preview, err := h.packService.Preview(c.Context(), c.Params("packId"))
+	if err == services.ErrPackNotFound {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Question pack not found"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to preview question pack"})
+	}
+
+	return c.JSON(preview)
+}
+
+// PublishPack handles POST /api/packs/:packId/publish
+func (h *PacksHandler) PublishPack(c *fiber.Ctx) error {
+	var req models.PublishPackRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+		}
+	}
+
+	version, err := h.packService.Publish(c.Context(), c.Params("packId"), req.Note)
+	switch err {
+	case nil:
+	case services.ErrPackNotFound:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Question pack not found"})
+	case services.ErrPackEmpty:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Add questions to the draft before publishing"})
+	case services.ErrPublishConflict:
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Another version was published at the same time, try again"})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to publish question pack"})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(version)
+}
+
+// RollbackPack handles POST /api/packs/:packId/rollback
+func (h *PacksHandler) RollbackPack(c *fiber.Ctx) error {
+	var req models.RollbackPackRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	pack, err := h.packService.Rollback(c.Context(), c.Params("packId"), req.Version)
+	switch err {
+	case nil:
+	case services.ErrPackNotFound:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Question pack not found"})
+	case services.ErrPackVersionNotFound:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Pack version not found"})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to roll back question pack"})
+	}
+
+	return c.JSON(pack)
+}
+
+// GetPackVersions handles GET /api/packs/:packId/versions
+func (h *PacksHandler) GetPackVersions(c *fiber.Ctx) error {
+	versions, err := h.packService.Versions(c.Context(), c.Params("packId"))
+	if err == services.ErrPackNotFound {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Question pack not found"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch pack versions"})
+	}
+
+	return c.JSON(versions)
+}
+
+// GetPackVersion handles GET /api/packs/:packId/versions/:version
+func (h *PacksHandler) GetPackVersion(c *fiber.Ctx) error {
+	number, err := c.ParamsInt("version")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid version"})
+	}
+
+	version, err := h.packService.Version(c.Context(), c.Params("packId"), number)
+	if err == services.ErrPackNotFound {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Question pack not found"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Pack version not found"})
+	}
+
+	return c.JSON(version)
+}
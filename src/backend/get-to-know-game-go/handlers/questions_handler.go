@@ -1,43 +1,85 @@
 package handlers
 
 import (
+	"errors"
+	"net/url"
+	"strings"
+
 	"get-to-know-game-go/models"
 	"get-to-know-game-go/repositories"
+	"get-to-know-game-go/services"
 
 	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // QuestionsHandler handles question-related HTTP requests
 type QuestionsHandler struct {
-	questionRepo repositories.QuestionRepository
+	questionRepo    repositories.QuestionRepository
+	sectionService  *services.SectionService
+	bankService     *services.QuestionBankService
+	localization    *services.LocalizationService
+	packService     *services.PackService
+	audienceService *services.AudienceService
 }
 
 // NewQuestionsHandler creates a new questions handler
-func NewQuestionsHandler(questionRepo repositories.QuestionRepository) *QuestionsHandler {
+func NewQuestionsHandler(questionRepo repositories.QuestionRepository, sectionService *services.SectionService, bankService *services.QuestionBankService, localization *services.LocalizationService, packService *services.PackService, audienceService *services.AudienceService) *QuestionsHandler {
 	return &QuestionsHandler{
-		questionRepo: questionRepo,
+		questionRepo:    questionRepo,
+		sectionService:  sectionService,
+		bankService:     bankService,
+		localization:    localization,
+		packService:     packService,
+		audienceService: audienceService,
 	}
 }
 
-// GetQuestions handles GET /api/questions
+// GetQuestions handles GET /api/questions, listing the draft of the pack given by ?pack (ID or key,
+// the default pack if omitted). Questions come in section order, then question order;
+// ?includeInactive=true adds inactive sections. ?audience= and ?tags= (comma-separated) narrow the list.
 func (h *QuestionsHandler) GetQuestions(c *fiber.Ctx) error {
-	questions, err := h.questionRepo.GetAll(c.Context())
+	filter, err := h.audienceService.Filter(c.Query("audience"), models.ParseTags(c.Query("tags")))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":     "Invalid audience",
+			"audiences": models.AllRatings(),
+		})
+	}
+
+	pack, err := h.packService.GetPack(c.Context(), c.Query("pack"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Question pack not found"})
+	}
+
+	questions, err := h.packService.DraftQuestions(c.Context(), pack, c.QueryBool("includeInactive"))
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch questions"})
 	}
+	questions = h.audienceService.Apply(questions, filter)
 
-	return c.JSON(questions)
+	localizer, err := requestLocalizer(c, h.localization)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch questions"})
+	}
+
+	return c.JSON(localizer.Questions(questions))
 }
 
 // GetQuestion handles GET /api/questions/:id
 func (h *QuestionsHandler) GetQuestion(c *fiber.Ctx) error {
 	id := c.Params("id")
 	question, err := h.questionRepo.GetByID(c.Context(), id)
-	if err != nil {
+	if err != nil || !h.audienceService.Allowed(question) {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Question not found"})
 	}
 
-	return c.JSON(question)
+	localizer, err := requestLocalizer(c, h.localization)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch questions"})
+	}
+
+	return c.JSON(localizer.Question(question))
 }
 
 // CreateQuestion handles POST /api/questions
@@ -47,12 +89,59 @@ func (h *QuestionsHandler) CreateQuestion(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
 	}
 
+	if req.ResponseType != "" && !models.IsValidAnswerScale(req.ResponseType) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":         "Invalid response type",
+			"responseTypes": models.AllAnswerScales(),
+		})
+	}
+	if req.Weight != nil && !models.IsValidWeight(*req.Weight) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Weight must be greater than 0 and at most 10"})
+	}
+	if req.Rating != "" && !models.IsValidRating(req.Rating) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid rating",
+			"ratings": models.AllRatings(),
+		})
+	}
+
+	if err := services.ValidateQuestionTranslations(req.Translations); err != nil {
+		return validationErrorResponse(c, err, "Failed to create question")
+	}
+
+	section, err := h.questionSection(c, req.SectionID, req.Section)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Section not found"})
+	}
+
+	pack, err := h.packService.GetPack(c.Context(), req.PackID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Question pack not found"})
+	}
+
+	key := strings.TrimSpace(req.Key)
+	if key == "" {
+		key = models.QuestionKey(section.Name, req.QuestionText)
+	}
+
 	question := models.Question{
-		Section:      req.Section,
+		Section:      section.Name,
+		SectionID:    section.ID,
+		Order:        req.Order,
 		QuestionText: req.QuestionText,
+		ResponseType: req.ResponseType,
+		Weight:       req.Weight,
+		Key:          key,
+		Translations: req.Translations,
+		PackID:       pack.ID,
+		Tags:         models.NormalizeTags(req.Tags),
+		Rating:       req.Rating,
 	}
 
 	createdQuestion, err := h.questionRepo.Create(c.Context(), question)
+	if mongo.IsDuplicateKeyError(err) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "A question with this key already exists"})
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create question"})
 	}
@@ -60,7 +149,7 @@ func (h *QuestionsHandler) CreateQuestion(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(createdQuestion)
 }
 
-// UpdateQuestion handles PUT /api/questions/:id
+// UpdateQuestion handles PUT /api/questions/:id; omitted fields keep their current values
 func (h *QuestionsHandler) UpdateQuestion(c *fiber.Ctx) error {
 	id := c.Params("id")
 	var req models.UpdateQuestionRequest
@@ -68,16 +157,91 @@ func (h *QuestionsHandler) UpdateQuestion(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
 	}
 
-	question := models.Question{
-		Section:      req.Section,
-		QuestionText: req.QuestionText,
+	if req.ResponseType != "" && !models.IsValidAnswerScale(req.ResponseType) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":         "Invalid response type",
+			"responseTypes": models.AllAnswerScales(),
+		})
+	}
+	if req.Weight != nil && *req.Weight != 0 && !models.IsValidWeight(*req.Weight) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Weight must be greater than 0 and at most 10"})
+	}
+	if req.Rating != nil && *req.Rating != "" && !models.IsValidRating(*req.Rating) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid rating",
+			"ratings": models.AllRatings(),
+		})
+	}
+
+	if err := services.ValidateQuestionTranslations(req.Translations); err != nil {
+		return validationErrorResponse(c, err, "Failed to update question")
 	}
 
-	err := h.questionRepo.Update(c.Context(), id, question)
+	question, err := h.questionRepo.GetByID(c.Context(), id)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Question not found"})
 	}
 
+	// Only the fields in the request are written, so omitted ones keep their values and
+	// emptied ones are removed rather than skipped by omitempty
+	set := map[string]interface{}{}
+	var unset []string
+	if req.SectionID != "" || req.Section != "" {
+		section, err := h.questionSection(c, req.SectionID, req.Section)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Section not found"})
+		}
+		set["section"], set["sectionId"] = section.Name, section.ID
+	}
+	if req.Order != nil {
+		set["order"] = *req.Order
+	}
+	if req.QuestionText != "" {
+		set["questionText"] = req.QuestionText
+	}
+	if req.ResponseType != "" {
+		set["responseType"] = req.ResponseType
+	}
+	if req.Weight != nil {
+		if *req.Weight == 0 {
+			unset = append(unset, "weight")
+		} else {
+			set["weight"] = *req.Weight
+		}
+	}
+	if req.Translations != nil {
+		if len(req.Translations) == 0 {
+			unset = append(unset, "translations")
+		} else {
+			set["translations"] = req.Translations
+		}
+	}
+	if req.PackID != "" {
+		pack, err := h.packService.GetPack(c.Context(), req.PackID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Question pack not found"})
+		}
+		set["packId"] = pack.ID
+	}
+	if req.Tags != nil {
+		if tags := models.NormalizeTags(*req.Tags); len(tags) > 0 {
+			set["tags"] = tags
+		} else {
+			unset = append(unset, "tags")
+		}
+	}
+	if req.Rating != nil {
+		if *req.Rating == "" {
+			unset = append(unset, "rating")
+		} else {
+			set["rating"] = *req.Rating
+		}
+	}
+
+	if err := h.questionRepo.UpdateFields(c.Context(), question.ID, set, unset); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update question"})
+	}
+
 	return c.JSON(fiber.Map{"message": "Question updated successfully"})
 }
 
@@ -91,3 +255,131 @@ func (h *QuestionsHandler) DeleteQuestion(c *fiber.Ctx) error {
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// ExportQuestions handles GET /api/questions/export?format=csv|json|yaml; the format defaults to JSON
+func (h *QuestionsHandler) ExportQuestions(c *fiber.Ctx) error {
+	format := strings.ToLower(c.Query("format", models.QuestionBankJSON))
+	if !models.IsValidQuestionBankFormat(format) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Format must be csv, json or yaml"})
+	}
+
+	data, err := h.bankService.Export(c.Context(), format)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to export questions"})
+	}
+
+	c.Set(fiber.HeaderContentType, services.QuestionBankContentType(format))
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="questions.`+format+`"`)
+	return c.Send(data)
+}
+
+// ImportQuestions handles POST /api/questions/import with the file as the request body.
+// The format comes from ?format or else the Content-Type. ?dryRun=true only reports what would
+// change; ?onError=skip imports the valid rows instead of rejecting a file with invalid rows.
+func (h *QuestionsHandler) ImportQuestions(c *fiber.Ctx) error {
+	format := strings.ToLower(c.Query("format", importFormat(c.Get(fiber.HeaderContentType))))
+	if !models.IsValidQuestionBankFormat(format) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Format must be csv, json or yaml"})
+	}
+
+	onError := c.Query("onError", "fail")
+	if onError != "fail" && onError != "skip" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "onError must be fail or skip"})
+	}
+
+	report, err := h.bankService.Import(c.Context(), c.Body(), models.ImportOptions{
+		Format:      format,
+		DryRun:      c.QueryBool("dryRun"),
+		SkipInvalid: onError == "skip",
+	})
+	if errors.Is(err, services.ErrInvalidQuestionBank) {
+		return validationErrorResponse(c, err, "Failed to import questions")
+	}
+	if errors.Is(err, services.ErrQuestionBankRejected) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(report)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to import questions"})
+	}
+
+	return c.JSON(report)
+}
+
+// GetSectionWeights handles GET /api/questions/sections/weights
+func (h *QuestionsHandler) GetSectionWeights(c *fiber.Ctx) error {
+	sectionWeights, err := h.sectionService.SectionWeights(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch section weights"})
+	}
+
+	return c.JSON(sectionWeights)
+}
+
+// UpdateSectionWeight handles PUT /api/questions/sections/:section/weight, where :section is the
+// section's ID or name
+func (h *QuestionsHandler) UpdateSectionWeight(c *fiber.Ctx) error {
+	section, err := url.PathUnescape(c.Params("section"))
+	if err != nil || strings.TrimSpace(section) == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid section"})
+	}
+
+	var req models.UpdateSectionWeightRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if !models.IsValidWeight(req.Weight) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Weight must be greater than 0 and at most 10"})
+	}
+
+	sectionWeight, err := h.sectionService.SetSectionWeight(c.Context(), section, req.Weight)
+	if errors.Is(err, services.ErrSectionNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Section not found"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update section weight"})
+	}
+
+	return c.JSON(sectionWeight)
+}
+
+// DeleteSectionWeight handles DELETE /api/questions/sections/:section/weight, where :section is the
+// section's ID or name
+func (h *QuestionsHandler) DeleteSectionWeight(c *fiber.Ctx) error {
+	section, err := url.PathUnescape(c.Params("section"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid section"})
+	}
+
+	err = h.sectionService.ResetSectionWeight(c.Context(), section)
+	if errors.Is(err, services.ErrSectionNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Section not found"})
+	}
+	if errors.Is(err, services.ErrSectionWeightNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Section weight not found"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update section weight"})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// questionSection resolves the section of a question by ID, or by name when no ID is given
+func (h *QuestionsHandler) questionSection(c *fiber.Ctx, sectionID, sectionName string) (models.Section, error) {
+	if sectionID != "" {
+		return h.sectionService.GetSection(c.Context(), sectionID)
+	}
+	return h.sectionService.ResolveSection(c.Context(), sectionName)
+}
+
+// importFormat guesses the question bank format from a Content-Type, defaulting to JSON
+func importFormat(contentType string) string {
+	switch {
+	case strings.Contains(contentType, "csv"):
+		return models.QuestionBankCSV
+	case strings.Contains(contentType, "yaml"):
+		return models.QuestionBankYAML
+	}
+	return models.QuestionBankJSON
+}
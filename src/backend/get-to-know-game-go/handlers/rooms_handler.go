@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// participantMatchLimit is how many top matches a participant sees
+const participantMatchLimit = 3
+
+var errParticipantNotFound = errors.New("participant not found")
+
+// RoomsHandler handles party room HTTP requests
+type RoomsHandler struct {
+	roomService  *services.RoomService
+	tokenService *services.TokenService
+	localization *services.LocalizationService
+}
+
+// NewRoomsHandler creates a new rooms handler
+func NewRoomsHandler(roomService *services.RoomService, tokenService *services.TokenService, localization *services.LocalizationService) *RoomsHandler {
+	return &RoomsHandler{
+		roomService:  roomService,
+		tokenService: tokenService,
+		localization: localization,
+	}
+}
+
+// CreateRoom handles POST /api/rooms
+func (h *RoomsHandler) CreateRoom(c *fiber.Ctx) error {
+	var req models.CreateRoomRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Room name is required"})
+	}
+
+	room, hostToken, err := h.roomService.CreateRoom(c.Context(), req.Name, req.Pack)
+	if err == services.ErrPackNotFound {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Question pack not found"})
+	}
+	if err == services.ErrPackNotPublished {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Question pack has not been published"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create room"})
+	}
+
+	response := fiber.Map{
+		"roomId":    room.ID.Hex(),
+		"name":      room.Name,
+		"joinCode":  room.JoinCode,
+		"link":      "/room/" + room.ID.Hex(),
+		"expiresAt": room.ExpiresAt,
+		"hostToken": hostToken,
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// GetRoom handles GET /api/rooms/:roomId
+func (h *RoomsHandler) GetRoom(c *fiber.Ctx) error {
+	room, err := h.roomService.GetRoom(c.Context(), c.Params("roomId"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Room not found"})
+	}
+
+	answeredCount := 0
+	for _, participant := range room.Participants {
+		if participant.HasAnswered() {
+			answeredCount++
+		}
+	}
+
+	response := fiber.Map{
+		"roomId":           room.ID.Hex(),
+		"name":             room.Name,
+		"joinCode":         room.JoinCode,
+		"expiresAt":        room.ExpiresAt,
+		"questionCount":    len(room.QuestionIDs),
+		"participants":     room.Participants,
+		"participantCount": len(room.Participants),
+		"answeredCount":    answeredCount,
+	}
+
+	return c.JSON(response)
+}
+
+// GetRoomQuestions handles GET /api/rooms/:roomId/questions
+func (h *RoomsHandler) GetRoomQuestions(c *fiber.Ctx) error {
+	room, err := h.roomService.GetRoom(c.Context(), c.Params("roomId"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Room not found"})
+	}
+
+	questions, err := h.roomService.Questions(c.Context(), room)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch questions"})
+	}
+
+	localizer, err := requestLocalizer(c, h.localization)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch questions"})
+	}
+
+	return c.JSON(localizer.Questions(questions))
+}
+
+// JoinRoom handles POST /api/rooms/:roomId/participants
+func (h *RoomsHandler) JoinRoom(c *fiber.Ctx) error {
+	var req models.JoinRoomRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Name is required"})
+	}
+
+	room, err := h.roomService.GetRoom(c.Context(), c.Params("roomId"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Room not found"})
+	}
+
+	participant, token, err := h.roomService.Join(c.Context(), room, req.Name)
+	if err != nil {
+		return roomErrorResponse(c, err, "Failed to join room")
+	}
+
+	response := fiber.Map{
+		"participantId": participant.ID.Hex(),
+		"token":         token,
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// SubmitRoomAnswers handles PUT /api/rooms/:roomId/participants/:participantId/answers
+func (h *RoomsHandler) SubmitRoomAnswers(c *fiber.Ctx) error {
+	var req models.SubmitRoomAnswersRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	room, err := h.roomService.GetRoom(c.Context(), c.Params("roomId"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Room not found"})
+	}
+
+	participant, err := h.authorizedParticipant(c, room)
+	if err != nil {
+		return participantErrorResponse(c, err)
+	}
+
+	if err := h.roomService.SubmitAnswers(c.Context(), room, participant, req.Answers); err != nil {
+		return roomErrorResponse(c, err, "Failed to submit answers")
+	}
+
+	return c.JSON(fiber.Map{"message": "Answers submitted successfully"})
+}
+
+// GetLeaderboard handles GET /api/rooms/:roomId/leaderboard (host only)
+func (h *RoomsHandler) GetLeaderboard(c *fiber.Ctx) error {
+	room, err := h.roomService.GetRoom(c.Context(), c.Params("roomId"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Room not found"})
+	}
+
+	token := bearerToken(c)
+	if token == "" {
+		return authErrorResponse(c, errMissingToken)
+	}
+	if !h.tokenService.Matches(room.HostTokenHash, token) {
+		return authErrorResponse(c, errInvalidToken)
+	}
+
+	pairs, err := h.roomService.Leaderboard(c.Context(), room, c.QueryInt("limit", 10))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to calculate leaderboard"})
+	}
+
+	return c.JSON(fiber.Map{
+		"roomId": room.ID.Hex(),
+		"pairs":  pairs,
+	})
+}
+
+// GetParticipantMatches handles GET /api/rooms/:roomId/participants/:participantId/matches
+func (h *RoomsHandler) GetParticipantMatches(c *fiber.Ctx) error {
+	room, err := h.roomService.GetRoom(c.Context(), c.Params("roomId"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Room not found"})
+	}
+
+	participant, err := h.authorizedParticipant(c, room)
+	if err != nil {
+		return participantErrorResponse(c, err)
+	}
+
+	matches, err := h.roomService.TopMatches(c.Context(), room, participant, participantMatchLimit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to calculate matches"})
+	}
+
+	return c.JSON(fiber.Map{
+		"participantId": participant.ID.Hex(),
+		"matches":       matches,
+	})
+}
+
+// authorizedParticipant finds the participant from the route and checks the caller's token
+func (h *RoomsHandler) authorizedParticipant(c *fiber.Ctx, room models.Room) (models.RoomParticipant, error) {
+	participantID := c.Params("participantId")
+	for _, participant := range room.Participants {
+		if participant.ID.Hex() != participantID {
+			continue
+		}
+
+		token := bearerToken(c)
+		if token == "" {
+			return participant, errMissingToken
+		}
+		if !h.tokenService.Matches(participant.TokenHash, token) {
+			return participant, errInvalidToken
+		}
+		return participant, nil
+	}
+
+	return models.RoomParticipant{}, errParticipantNotFound
+}
+
+// participantErrorResponse maps participant lookup and authentication errors to responses
+func participantErrorResponse(c *fiber.Ctx, err error) error {
+	if errors.Is(err, errParticipantNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Participant not found"})
+	}
+	return authErrorResponse(c, err)
+}
+
+// roomErrorResponse maps room service errors to responses; other errors are reported with fallback
+func roomErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrRoomClosed):
+		return c.Status(fiber.StatusGone).JSON(fiber.Map{"error": "Room has expired"})
+	case errors.Is(err, services.ErrRoomFull):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Room is full"})
+	case errors.Is(err, services.ErrAlreadyAnswered):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Answers have already been submitted"})
+	default:
+		return validationErrorResponse(c, err, fallback)
+	}
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SectionsHandler handles section-related HTTP requests
+type SectionsHandler struct {
+	sectionService *services.SectionService
+	localization   *services.LocalizationService
+}
+
+// NewSectionsHandler creates a new sections handler
+func NewSectionsHandler(sectionService *services.SectionService, localization *services.LocalizationService) *SectionsHandler {
+	return &SectionsHandler{
+		sectionService: sectionService,
+		localization:   localization,
+	}
+}
+
+// GetSections handles GET /api/sections
+func (h *SectionsHandler) GetSections(c *fiber.Ctx) error {
+	sections, err := h.sectionService.ListSections(c.Context(), c.QueryBool("includeInactive"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch sections"})
+	}
+
+	localizer, err := requestLocalizer(c, h.localization)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch sections"})
+	}
+
+	return c.JSON(localizer.Sections(sections))
+}
+
+// GetSection handles GET /api/sections/:id
+func (h *SectionsHandler) GetSection(c *fiber.Ctx) error {
+	section, err := h.sectionService.GetSection(c.Context(), c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Section not found"})
+	}
+
+	return c.JSON(section.Localized(language(c)))
+}
+
+// CreateSection handles POST /api/sections
+func (h *SectionsHandler) CreateSection(c *fiber.Ctx) error {
+	var req models.CreateSectionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	section, err := h.sectionService.CreateSection(c.Context(), req)
+	if err == services.ErrSectionExists {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "A section with this name already exists"})
+	}
+	if err != nil {
+		return validationErrorResponse(c, err, "Failed to create section")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(section)
+}
+
+// UpdateSection handles PUT /api/sections/:id
+func (h *SectionsHandler) UpdateSection(c *fiber.Ctx) error {
+	var req models.UpdateSectionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if _, err := h.sectionService.GetSection(c.Context(), c.Params("id")); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Section not found"})
+	}
+
+	section, err := h.sectionService.UpdateSection(c.Context(), c.Params("id"), req)
+	if err == services.ErrSectionExists {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "A section with this name already exists"})
+	}
+	if err != nil {
+		return validationErrorResponse(c, err, "Failed to update section")
+	}
+
+	return c.JSON(section)
+}
+
+// DeleteSection handles DELETE /api/sections/:id
+func (h *SectionsHandler) DeleteSection(c *fiber.Ctx) error {
+	err := h.sectionService.DeleteSection(c.Context(), c.Params("id"))
+	if err == services.ErrSectionInUse {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Move or delete the section's questions first"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Section not found"})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
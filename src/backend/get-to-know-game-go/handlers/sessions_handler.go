@@ -2,45 +2,118 @@ package handlers
 
 import (
 	"fmt"
+	"log"
+	"time"
+
 	"get-to-know-game-go/models"
 	"get-to-know-game-go/repositories"
 	"get-to-know-game-go/services"
 
 	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // SessionsHandler handles session-related HTTP requests
 type SessionsHandler struct {
-	sessionRepo           repositories.GameSessionRepository
-	playerRepo            repositories.PlayerRepository
-	compatibilityService  *services.CompatibilityService
+	sessionRepo            repositories.GameSessionRepository
+	playerRepo             repositories.PlayerRepository
+	sessionQuestionService *services.SessionQuestionService
+	sessionScoringService  *services.SessionScoringService
+	predictionService      *services.PredictionService
+	resultsService         *services.ResultsService
+	distributionService    *services.ScoreDistributionService
+	tokenService           *services.TokenService
+	joinCodeService        *services.JoinCodeService
+	localization           *services.LocalizationService
+	sessionTTL             time.Duration
 }
 
 // NewSessionsHandler creates a new sessions handler
 func NewSessionsHandler(
 	sessionRepo repositories.GameSessionRepository,
 	playerRepo repositories.PlayerRepository,
-	compatibilityService *services.CompatibilityService,
+	sessionQuestionService *services.SessionQuestionService,
+	sessionScoringService *services.SessionScoringService,
+	predictionService *services.PredictionService,
+	resultsService *services.ResultsService,
+	distributionService *services.ScoreDistributionService,
+	tokenService *services.TokenService,
+	joinCodeService *services.JoinCodeService,
+	localization *services.LocalizationService,
+	sessionTTL time.Duration,
 ) *SessionsHandler {
 	return &SessionsHandler{
-		sessionRepo:          sessionRepo,
-		playerRepo:           playerRepo,
-		compatibilityService: compatibilityService,
+		sessionRepo:            sessionRepo,
+		playerRepo:             playerRepo,
+		sessionQuestionService: sessionQuestionService,
+		sessionScoringService:  sessionScoringService,
+		predictionService:      predictionService,
+		resultsService:         resultsService,
+		distributionService:    distributionService,
+		tokenService:           tokenService,
+		joinCodeService:        joinCodeService,
+		localization:           localization,
+		sessionTTL:             sessionTTL,
 	}
 }
 
 // CreateSession handles POST /api/sessions
 func (h *SessionsHandler) CreateSession(c *fiber.Ctx) error {
 	fmt.Printf("=== CreateSession endpoint called ===\n")
-	
+
 	var req models.CreateSessionRequest
 	if err := c.BodyParser(&req); err != nil {
 		fmt.Printf("Error parsing request body: %v\n", err)
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
 	}
-	
+
 	fmt.Printf("Request parsed successfully: Player1Name=%s, Player2Name=%s\n", req.Player1Name, req.Player2Name)
 
+	if req.Mode == "" {
+		req.Mode = models.GameModeClassic
+	}
+	if !models.IsValidGameMode(req.Mode) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid game mode"})
+	}
+
+	customQuestions, err := h.sessionQuestionService.BuildCustomQuestions(req.CustomQuestions)
+	if err != nil {
+		return validationErrorResponse(c, err, "Failed to create session")
+	}
+
+	shuffle, shuffleSeed, err := h.sessionQuestionService.NewShuffle(req.Shuffle)
+	if err == services.ErrInvalidShuffleMode {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":        "Invalid shuffle mode",
+			"shuffleModes": models.AllShuffleModes(),
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create session"})
+	}
+
+	filter, err := h.sessionQuestionService.QuestionFilter(req.Audience, req.Tags)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":     "Invalid audience",
+			"audiences": models.AllRatings(),
+		})
+	}
+
+	pack, err := h.sessionQuestionService.PinPack(c.Context(), req.Pack, filter)
+	if err == services.ErrNoMatchingQuestions {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "No questions match the audience and tags"})
+	}
+	if err == services.ErrPackNotFound {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Question pack not found"})
+	}
+	if err == services.ErrPackNotPublished {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Question pack has not been published"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create session"})
+	}
+
 	// Create Player 1
 	player1 := models.Player{Name: req.Player1Name}
 	fmt.Printf("Creating Player 1: %s\n", req.Player1Name)
@@ -51,31 +124,68 @@ func (h *SessionsHandler) CreateSession(c *fiber.Ctx) error {
 	}
 	fmt.Printf("Player 1 created successfully with ID: %s\n", createdPlayer1.ID.Hex())
 
+	player1Token, player1TokenHash, err := h.tokenService.GenerateToken()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to generate access token"})
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(h.sessionTTL)
+
+	// The join code is issued before the session is stored, so a session never exists without one
+	sessionID := primitive.NewObjectID()
+	joinCode, err := h.joinCodeService.Issue(c.Context(), sessionID, expiresAt)
+	if err != nil {
+		fmt.Printf("Error issuing join code: %v\n", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create join code"})
+	}
+
 	// Create GameSession
 	session := models.GameSession{
-		Player1ID:      createdPlayer1.ID,
-		Player1Answers: []models.PlayerAnswer{},
-		Player2Name:    &req.Player2Name,
+		ID:               sessionID,
+		Mode:             req.Mode,
+		Player1ID:        createdPlayer1.ID,
+		Player1Answers:   []models.PlayerAnswer{},
+		Player2Name:      &req.Player2Name,
+		Player1TokenHash: player1TokenHash,
+		CreatedAt:        &now,
+		ExpiresAt:        &expiresAt,
+		CustomQuestions:  customQuestions,
+		PackID:           pack.ID,
+		PackVersion:      pack.PublishedVersion,
+		Audience:         filter.Audience,
+		Tags:             filter.Tags,
+		Shuffle:          shuffle,
+		ShuffleSeed:      shuffleSeed,
+		JoinCode:         joinCode,
 	}
 
 	fmt.Printf("Creating GameSession for Player 1: %s\n", createdPlayer1.ID.Hex())
 	createdSession, err := h.sessionRepo.Create(c.Context(), session)
 	if err != nil {
 		fmt.Printf("Error creating GameSession: %v\n", err)
+		if err := h.joinCodeService.Release(c.Context(), sessionID); err != nil {
+			log.Printf("Failed to release join code of session %s: %v", sessionID.Hex(), err)
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create session"})
 	}
 	fmt.Printf("GameSession created successfully with ID: %s\n", createdSession.ID.Hex())
 
 	response := fiber.Map{
-		"sessionId": createdSession.ID.Hex(),
-		"link":      "/session/" + createdSession.ID.Hex(),
-		"player1Id": createdPlayer1.ID.Hex(),
+		"sessionId":    createdSession.ID.Hex(),
+		"link":         "/session/" + createdSession.ID.Hex(),
+		"joinCode":     joinCode,
+		"expiresAt":    expiresAt,
+		"player1Id":    createdPlayer1.ID.Hex(),
+		"player1Token": player1Token,
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(response)
 }
 
 // GetSession handles GET /api/sessions/:sessionId
+// The response is projected for the player owning the access token, if any:
+// a player only ever sees their own answers, never their partner's.
 func (h *SessionsHandler) GetSession(c *fiber.Ctx) error {
 	sessionID := c.Params("sessionId")
 	session, err := h.sessionRepo.GetByID(c.Context(), sessionID)
@@ -83,6 +193,15 @@ func (h *SessionsHandler) GetSession(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Session not found"})
 	}
 
+	var viewerID string
+	if bearerToken(c) != "" {
+		playerID, err := authenticateSessionPlayer(c, h.tokenService, session)
+		if err != nil {
+			return authErrorResponse(c, err)
+		}
+		viewerID = playerID.Hex()
+	}
+
 	// Get player names
 	player1, err := h.playerRepo.GetByID(c.Context(), session.Player1ID.Hex())
 	if err != nil {
@@ -90,20 +209,29 @@ func (h *SessionsHandler) GetSession(c *fiber.Ctx) error {
 	}
 
 	// Determine game completion status
+	isPlayer1Completed := len(session.Player1Answers) > 0
 	isPlayer2Joined := session.Player2ID != nil
 	isPlayer2Completed := session.Player2Answers != nil && len(*session.Player2Answers) > 0
-	isGameComplete := isPlayer2Joined && isPlayer2Completed && session.CompatibilityScore != nil
+	isGameComplete := session.IsComplete()
 
 	response := fiber.Map{
-		"sessionId": session.ID.Hex(),
-		"player1Id": session.Player1ID.Hex(),
-		"player1Name": player1.Name,
-		"player2Name": session.Player2Name,
-		"player1Answers": session.Player1Answers,
+		"sessionId":          session.ID.Hex(),
+		"mode":               session.GameMode(),
+		"player1Id":          session.Player1ID.Hex(),
+		"player1Name":        player1.Name,
+		"player2Name":        session.Player2Name,
+		"joinCode":           session.JoinCode,
+		"expiresAt":          session.ExpiresAt,
 		"compatibilityScore": session.CompatibilityScore,
-		"isPlayer2Joined": isPlayer2Joined,
+		"sectionScores":      session.SectionScores,
+		"isPlayer1Completed": isPlayer1Completed,
+		"isPlayer2Joined":    isPlayer2Joined,
 		"isPlayer2Completed": isPlayer2Completed,
-		"isGameComplete": isGameComplete,
+		"isGameComplete":     isGameComplete,
+	}
+
+	if viewerID != "" && viewerID == session.Player1ID.Hex() {
+		response["player1Answers"] = session.Player1Answers
 	}
 
 	// Add player 2 info if they've joined
@@ -113,7 +241,7 @@ func (h *SessionsHandler) GetSession(c *fiber.Ctx) error {
 			response["player2Id"] = session.Player2ID.Hex()
 			response["player2Name"] = player2.Name
 		}
-		if session.Player2Answers != nil {
+		if viewerID == session.Player2ID.Hex() && session.Player2Answers != nil {
 			response["player2Answers"] = session.Player2Answers
 		}
 	}
@@ -140,6 +268,10 @@ func (h *SessionsHandler) JoinSession(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Session is already full"})
 	}
 
+	if session.IsExpired(time.Now()) {
+		return c.Status(fiber.StatusGone).JSON(fiber.Map{"error": "Session has expired"})
+	}
+
 	// Create Player 2
 	player2 := models.Player{Name: req.Player2Name}
 	createdPlayer2, err := h.playerRepo.Create(c.Context(), player2)
@@ -147,15 +279,21 @@ func (h *SessionsHandler) JoinSession(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create player 2"})
 	}
 
+	player2Token, player2TokenHash, err := h.tokenService.GenerateToken()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to generate access token"})
+	}
+
 	// Update the session with player 2
-	err = h.sessionRepo.UpdatePlayer2(c.Context(), sessionID, createdPlayer2.ID)
+	err = h.sessionRepo.UpdatePlayer2(c.Context(), sessionID, createdPlayer2.ID, player2TokenHash)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to join session"})
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Failed to join session"})
 	}
 
 	response := fiber.Map{
-		"player2Id": createdPlayer2.ID.Hex(),
-		"message":   "Successfully joined session",
+		"player2Id":    createdPlayer2.ID.Hex(),
+		"player2Token": player2Token,
+		"message":      "Successfully joined session",
 	}
 
 	return c.JSON(response)
@@ -175,43 +313,240 @@ func (h *SessionsHandler) SubmitAnswers(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Session not found"})
 	}
 
-	// Update answers
-	err = h.sessionRepo.UpdateAnswers(c.Context(), sessionID, req.PlayerID, req.Answers)
+	playerID, err := authenticateSessionPlayer(c, h.tokenService, session)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return authErrorResponse(c, err)
+	}
+	if playerID.Hex() != req.PlayerID {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Access token does not belong to this player"})
+	}
+	if session.BroadcastID != nil && playerID == session.Player1ID {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Player 1 answers for a broadcast are submitted on the broadcast"})
+	}
+	if session.IsComplete() {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Answers can't be changed once the results are in"})
 	}
 
-	// If this is Player 2 submitting answers, calculate compatibility score
-	if session.Player2ID != nil && session.Player2ID.Hex() == req.PlayerID {
-		// Get updated session to ensure we have the latest answers
-		updatedSession, err := h.sessionRepo.GetByID(c.Context(), sessionID)
-		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch updated session"})
-		}
+	// In prediction mode every answer must also carry a guess of the partner's response
+	if err := h.sessionQuestionService.ValidateAnswers(c.Context(), session, req.Answers); err != nil {
+		return validationErrorResponse(c, err, "Failed to fetch questions")
+	}
 
-		if updatedSession.Player2Answers != nil {
-			score, err := h.compatibilityService.CalculateScore(updatedSession.Player1Answers, *updatedSession.Player2Answers)
-			if err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to calculate compatibility score"})
-			}
+	// Update answers
+	updated, err := h.sessionRepo.UpdateAnswers(c.Context(), sessionID, req.PlayerID, req.Answers)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to submit answers"})
+	}
+	if !updated {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Answers can't be changed once the results are in"})
+	}
 
-			err = h.sessionRepo.UpdateCompatibilityScore(c.Context(), sessionID, score)
-			if err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update compatibility score"})
-			}
-		}
+	// Calculate the compatibility score once both players have answered
+	if _, err := h.sessionScoringService.ScoreIfComplete(c.Context(), sessionID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to calculate compatibility score"})
 	}
 
 	return c.JSON(fiber.Map{"message": "Answers submitted successfully"})
 }
 
+// GetSessionQuestions handles GET /api/sessions/:sessionId/questions.
+// Each player gets the questions in their own language; question IDs are the same in every language.
+// Both players get the same order, shuffled from the session's seed.
+func (h *SessionsHandler) GetSessionQuestions(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+	session, err := h.sessionRepo.GetByID(c.Context(), sessionID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Session not found"})
+	}
+
+	questions, err := h.localizedQuestions(c, session)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch questions"})
+	}
+
+	return c.JSON(h.sessionQuestionService.Shuffled(session, questions))
+}
+
+// GetResults handles GET /api/sessions/:sessionId/results
+func (h *SessionsHandler) GetResults(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+	session, err := h.sessionRepo.GetByID(c.Context(), sessionID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Session not found"})
+	}
+
+	if _, err := authenticateSessionPlayer(c, h.tokenService, session); err != nil {
+		return authErrorResponse(c, err)
+	}
+
+	if !session.IsComplete() {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Session is not complete yet"})
+	}
+
+	if scorer := c.Query("scorer"); scorer != "" && !h.resultsService.HasScorer(scorer) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Unknown scorer",
+			"scorers": h.resultsService.ScorerNames(),
+		})
+	}
+
+	player1, err := h.playerRepo.GetByID(c.Context(), session.Player1ID.Hex())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch player 1"})
+	}
+
+	player2, err := h.playerRepo.GetByID(c.Context(), session.Player2ID.Hex())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch player 2"})
+	}
+
+	localizer, err := requestLocalizer(c, h.localization)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch questions"})
+	}
+
+	questions, err := h.sessionQuestionService.Questions(c.Context(), session)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch questions"})
+	}
+
+	results, err := h.resultsService.BuildResults(c.Context(), session, localizer.Questions(questions), player1.Name, player2.Name, c.Query("scorer"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to build results"})
+	}
+	// Section scores are stored under the sections' own names
+	for i := range results.SectionScores {
+		results.SectionScores[i].Section = localizer.SectionName(results.SectionScores[i].Section)
+	}
+	results.Percentile, results.QuestionSetPercentile = h.distributionService.Percentiles(c.Context(), session)
+
+	return c.JSON(results)
+}
+
+// ExplainScore handles GET /api/sessions/:sessionId/score/explain
+func (h *SessionsHandler) ExplainScore(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+	session, err := h.sessionRepo.GetByID(c.Context(), sessionID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Session not found"})
+	}
+
+	if _, err := authenticateSessionPlayer(c, h.tokenService, session); err != nil {
+		return authErrorResponse(c, err)
+	}
+
+	if !session.IsComplete() {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Session is not complete yet"})
+	}
+
+	questions, err := h.localizedQuestions(c, session)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch questions"})
+	}
+
+	explanation, err := h.resultsService.ExplainScore(session, questions)
+	if err != nil {
+		return validationErrorResponse(c, err, "Failed to explain score")
+	}
+
+	return c.JSON(explanation)
+}
+
+// GetPredictions handles GET /api/sessions/:sessionId/predictions
+func (h *SessionsHandler) GetPredictions(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+	session, err := h.sessionRepo.GetByID(c.Context(), sessionID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Session not found"})
+	}
+
+	if session.GameMode() != models.GameModePrediction {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Session is not in prediction mode"})
+	}
+
+	if _, err := authenticateSessionPlayer(c, h.tokenService, session); err != nil {
+		return authErrorResponse(c, err)
+	}
+
+	if !session.IsComplete() {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Session is not complete yet"})
+	}
+
+	player1Accuracy, player2Accuracy := h.predictionService.SessionAccuracy(session)
+
+	response := fiber.Map{
+		"sessionId":          session.ID.Hex(),
+		"compatibilityScore": session.CompatibilityScore,
+		"player1Accuracy":    player1Accuracy,
+		"player2Accuracy":    player2Accuracy,
+	}
+
+	return c.JSON(response)
+}
+
+// RotateToken handles POST /api/sessions/:sessionId/token/rotate
+func (h *SessionsHandler) RotateToken(c *fiber.Ctx) error {
+	sessionID := c.Params("sessionId")
+	session, err := h.sessionRepo.GetByID(c.Context(), sessionID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Session not found"})
+	}
+
+	playerID, err := authenticateSessionPlayer(c, h.tokenService, session)
+	if err != nil {
+		return authErrorResponse(c, err)
+	}
+
+	token, tokenHash, err := h.tokenService.GenerateToken()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to generate access token"})
+	}
+
+	err = h.sessionRepo.UpdateTokenHash(c.Context(), sessionID, playerID, tokenHash)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to rotate access token"})
+	}
+
+	return c.JSON(fiber.Map{
+		"playerId": playerID.Hex(),
+		"token":    token,
+	})
+}
+
 // DeleteSession handles DELETE /api/sessions/:sessionId
 func (h *SessionsHandler) DeleteSession(c *fiber.Ctx) error {
 	sessionID := c.Params("sessionId")
-	err := h.sessionRepo.Delete(c.Context(), sessionID)
+	session, err := h.sessionRepo.GetByID(c.Context(), sessionID)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Session not found"})
 	}
 
+	if _, err := authenticateSessionPlayer(c, h.tokenService, session); err != nil {
+		return authErrorResponse(c, err)
+	}
+
+	err = h.sessionRepo.Delete(c.Context(), sessionID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Session not found"})
+	}
+
+	if err := h.joinCodeService.Release(c.Context(), session.ID); err != nil {
+		log.Printf("Failed to release join code of session %s: %v", sessionID, err)
+	}
+
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// localizedQuestions returns the session's questions in the language of the request
+func (h *SessionsHandler) localizedQuestions(c *fiber.Ctx, session models.GameSession) ([]models.Question, error) {
+	questions, err := h.sessionQuestionService.Questions(c.Context(), session)
+	if err != nil {
+		return nil, err
+	}
+
+	localizer, err := requestLocalizer(c, h.localization)
+	if err != nil {
+		return nil, err
+	}
+	return localizer.Questions(questions), nil
+}
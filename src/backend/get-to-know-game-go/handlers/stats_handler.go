@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// errInvalidStatsDate is returned for a from or to date that is neither a date nor an RFC 3339 time
+var errInvalidStatsDate = errors.New("invalid date")
+
+// StatsHandler handles statistics HTTP requests
+type StatsHandler struct {
+	distributionService  *services.ScoreDistributionService
+	questionStatsService *services.QuestionStatsService
+	localization         *services.LocalizationService
+}
+
+// NewStatsHandler creates a new stats handler
+func NewStatsHandler(distributionService *services.ScoreDistributionService, questionStatsService *services.QuestionStatsService, localization *services.LocalizationService) *StatsHandler {
+	return &StatsHandler{
+		distributionService:  distributionService,
+		questionStatsService: questionStatsService,
+		localization:         localization,
+	}
+}
+
+// GetScoreDistribution handles GET /api/stats/score-distribution.
+// ?questionSet=<key> selects one question set instead of every completed session.
+func (h *StatsHandler) GetScoreDistribution(c *fiber.Ctx) error {
+	key := c.Query("questionSet", models.OverallDistributionKey)
+
+	distribution, err := h.distributionService.Distribution(c.Context(), key)
+	if err != nil {
+		// No session has been scored yet, so the distribution is empty
+		distribution = models.ScoreDistribution{Key: key}
+	}
+
+	response := fiber.Map{
+		"key":       distribution.Key,
+		"total":     distribution.Total,
+		"histogram": distribution.Histogram(),
+		"updatedAt": distribution.UpdatedAt,
+	}
+
+	if key == models.OverallDistributionKey {
+		distributions, err := h.distributionService.Distributions(c.Context())
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch score distributions"})
+		}
+
+		questionSets := []fiber.Map{}
+		for _, questionSet := range distributions {
+			if questionSet.Key != models.OverallDistributionKey {
+				questionSets = append(questionSets, fiber.Map{"key": questionSet.Key, "total": questionSet.Total})
+			}
+		}
+		response["questionSets"] = questionSets
+	}
+
+	return c.JSON(response)
+}
+
+// GetQuestionStats handles GET /api/questions/:id/stats, with the same date filters as GetQuestionsStats
+func (h *StatsHandler) GetQuestionStats(c *fiber.Ctx) error {
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Question not found"})
+	}
+
+	filter, err := questionStatsFilter(c, []primitive.ObjectID{id})
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Dates must be YYYY-MM-DD or RFC 3339 times"})
+	}
+
+	stats, err := h.localizedQuestionStats(c, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to compute question stats"})
+	}
+	if len(stats) == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Question not found"})
+	}
+
+	return c.JSON(stats[0])
+}
+
+// GetQuestionsStats handles GET /api/questions/stats, reporting the answer distribution, partner
+// agreement and match rate of the questions given by ?ids (comma-separated), or of every question.
+// ?from= and ?to= limit the sessions to those created in a date range; a date-only to is inclusive.
+func (h *StatsHandler) GetQuestionsStats(c *fiber.Ctx) error {
+	var ids []primitive.ObjectID
+	for _, value := range strings.Split(c.Query("ids"), ",") {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		id, err := primitive.ObjectIDFromHex(value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid question ID"})
+		}
+		ids = append(ids, id)
+	}
+
+	filter, err := questionStatsFilter(c, ids)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Dates must be YYYY-MM-DD or RFC 3339 times"})
+	}
+
+	stats, err := h.localizedQuestionStats(c, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to compute question stats"})
+	}
+
+	return c.JSON(stats)
+}
+
+// localizedQuestionStats computes question statistics with the questions in the request's language
+func (h *StatsHandler) localizedQuestionStats(c *fiber.Ctx, filter models.QuestionStatsFilter) ([]models.QuestionStats, error) {
+	stats, err := h.questionStatsService.Stats(c.Context(), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	localizer, err := requestLocalizer(c, h.localization)
+	if err != nil {
+		return nil, err
+	}
+	for i := range stats {
+		stats[i].Question = localizer.Question(stats[i].Question)
+	}
+	return stats, nil
+}
+
+// questionStatsFilter builds the statistics filter of the questions ids from the request's ?from and ?to
+func questionStatsFilter(c *fiber.Ctx, ids []primitive.ObjectID) (models.QuestionStatsFilter, error) {
+	from, err := parseStatsDate(c.Query("from"), false)
+	if err != nil {
+		return models.QuestionStatsFilter{}, err
+	}
+	to, err := parseStatsDate(c.Query("to"), true)
+	if err != nil {
+		return models.QuestionStatsFilter{}, err
+	}
+	return models.QuestionStatsFilter{QuestionIDs: ids, From: from, To: to}, nil
+}
+
+// parseStatsDate parses a date filter given as YYYY-MM-DD (UTC) or an RFC 3339 time. A date-only
+// end of range covers the whole day, so it becomes the start of the next day.
+func parseStatsDate(value string, endOfRange bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		if endOfRange {
+			date = date.AddDate(0, 0, 1)
+		}
+		return &date, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errInvalidStatsDate
+	}
+	return &parsed, nil
+}
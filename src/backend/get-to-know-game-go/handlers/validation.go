@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+
+	"get-to-know-game-go/i18n"
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// validationErrorResponse maps the validation errors of the services to 400 responses with a
+// message from the catalog, so they can be translated. Any other error is a server error
+// reported with the fallback message.
+func validationErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	var response fiber.Map
+	switch {
+	case errors.Is(err, services.ErrQuestionTextRequired):
+		response = fiber.Map{"error": "Question text is required"}
+	case errors.Is(err, services.ErrQuestionTextTooLong):
+		response = fiber.Map{"error": "Question text is too long", "maxLength": services.MaxQuestionTextLength}
+	case errors.Is(err, services.ErrSectionNameTooLong):
+		response = fiber.Map{"error": "Section name is too long", "maxLength": services.MaxSectionLength}
+	case errors.Is(err, services.ErrInappropriateLanguage):
+		response = fiber.Map{"error": "Question contains inappropriate language"}
+	case errors.Is(err, services.ErrTooManyCustomQuestions):
+		response = fiber.Map{"error": "Too many custom questions", "maxCustomQuestions": services.MaxCustomQuestions}
+	case errors.Is(err, services.ErrInvalidResponseType):
+		response = fiber.Map{"error": "Invalid response type", "responseTypes": models.AllAnswerScales()}
+	case errors.Is(err, services.ErrUnknownQuestion):
+		response = fiber.Map{"error": "Answer to an unknown question"}
+	case errors.Is(err, services.ErrDuplicateAnswer):
+		response = fiber.Map{"error": "A question was answered more than once"}
+	case errors.Is(err, services.ErrInvalidResponse):
+		response = fiber.Map{"error": "Invalid response to a question"}
+	case errors.Is(err, services.ErrPredictionRequired):
+		response = fiber.Map{"error": "Each answer must include a valid prediction"}
+	case errors.Is(err, services.ErrInvalidDealbreaker):
+		response = fiber.Map{"error": "Only a positive or negative answer can be a dealbreaker"}
+	case errors.Is(err, services.ErrTooManyDealbreakers):
+		response = fiber.Map{"error": "Too many dealbreakers", "maxDealbreakers": models.MaxDealbreakers}
+	case errors.Is(err, services.ErrUnansweredQuestions):
+		response = fiber.Map{"error": "All questions must be answered"}
+	case errors.Is(err, services.ErrPartnerNameRequired):
+		response = fiber.Map{"error": "At least one partner name is required"}
+	case errors.Is(err, services.ErrTooManyPartners):
+		response = fiber.Map{"error": "Too many partners", "maxPartners": services.MaxBroadcastPartners}
+	case errors.Is(err, services.ErrBroadcastEmpty):
+		response = fiber.Map{"error": "Broadcast has no invites"}
+	case errors.Is(err, services.ErrUnsupportedLanguage):
+		response = fiber.Map{"error": "Unsupported translation language", "languages": i18n.SupportedLanguages()}
+	case errors.Is(err, services.ErrTranslationTextRequired):
+		response = fiber.Map{"error": "Every translation needs question text"}
+	case errors.Is(err, services.ErrSectionNameRequired):
+		response = fiber.Map{"error": "Section name is required"}
+	case errors.Is(err, services.ErrPackNameRequired):
+		response = fiber.Map{"error": "Pack name is required"}
+	case errors.Is(err, services.ErrInvalidQuestionBank):
+		response = fiber.Map{"error": "Question bank file could not be parsed"}
+	case errors.Is(err, services.ErrScoreNotExplainable):
+		response = fiber.Map{"error": "Scores from this strategy can't be explained"}
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fallback})
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(response)
+}
@@ -0,0 +1,79 @@
+// Package i18n negotiates the language of a request and translates API messages
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Supported languages
+const (
+	English  = "en"
+	Romanian = "ro"
+	Spanish  = "es"
+)
+
+// SupportedLanguages returns the languages questions, sections and messages can be served in
+func SupportedLanguages() []string {
+	return []string{English, Romanian, Spanish}
+}
+
+// IsSupported reports whether language is a supported language code
+func IsSupported(language string) bool {
+	for _, supported := range SupportedLanguages() {
+		if language == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// Negotiate picks the language of a request: the ?lang= query value if supported, otherwise the
+// most preferred supported language of the Accept-Language header, otherwise fallback.
+// Regional variants such as "es-MX" match their base language.
+func Negotiate(query, acceptLanguage, fallback string) string {
+	if language := baseLanguage(query); IsSupported(language) {
+		return language
+	}
+
+	type preference struct {
+		language string
+		quality  float64
+	}
+	var preferences []preference
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		quality := 1.0
+		for _, parameter := range fields[1:] {
+			parameter = strings.TrimSpace(parameter)
+			if value, found := strings.CutPrefix(parameter, "q="); found {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+		if quality > 0 {
+			preferences = append(preferences, preference{language: baseLanguage(fields[0]), quality: quality})
+		}
+	}
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].quality > preferences[j].quality
+	})
+
+	for _, preference := range preferences {
+		if IsSupported(preference.language) {
+			return preference.language
+		}
+	}
+	return fallback
+}
+
+// baseLanguage returns the lowercase primary subtag of a language tag, e.g. "ro" for "ro-RO"
+func baseLanguage(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
@@ -0,0 +1,63 @@
+package i18n
+
+import "testing"
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		acceptLanguage string
+		want           string
+	}{
+		{name: "nothing asked", want: English},
+		{name: "query", query: "ro", acceptLanguage: "es", want: Romanian},
+		{name: "regional query", query: "es-MX", want: Spanish},
+		{name: "unsupported query falls through to header", query: "fr", acceptLanguage: "es", want: Spanish},
+		{name: "region fallback", acceptLanguage: "ro-RO", want: Romanian},
+		{name: "underscore region", acceptLanguage: "es_AR", want: Spanish},
+		{name: "case insensitive", acceptLanguage: "RO-ro", want: Romanian},
+		{name: "first listed wins at equal quality", acceptLanguage: "es, ro", want: Spanish},
+		{name: "highest quality wins", acceptLanguage: "es;q=0.5, ro;q=0.9", want: Romanian},
+		{name: "missing quality is 1", acceptLanguage: "es;q=0.8, ro", want: Romanian},
+		{name: "unsupported preferred language skipped", acceptLanguage: "fr-FR, de;q=0.9, es;q=0.1", want: Spanish},
+		{name: "zero quality excluded", acceptLanguage: "ro;q=0, fr", want: English},
+		{name: "unknown language", acceptLanguage: "fr-FR, de;q=0.8", want: English},
+		{name: "wildcard", acceptLanguage: "*", want: English},
+		{name: "malformed quality counts as 1", acceptLanguage: "ro;q=abc, es;q=0.9", want: Romanian},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.query, tt.acceptLanguage, English); got != tt.want {
+				t.Errorf("Negotiate(%q, %q) = %q, want %q", tt.query, tt.acceptLanguage, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNegotiateUsesFallback(t *testing.T) {
+	if got := Negotiate("", "fr", Spanish); got != Spanish {
+		t.Errorf("Negotiate with an unknown language = %q, want the fallback %q", got, Spanish)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		message  string
+		want     string
+	}{
+		{name: "catalogued", language: Romanian, message: "Session not found", want: "Sesiunea nu a fost găsită"},
+		{name: "english", language: English, message: "Session not found", want: "Session not found"},
+		{name: "not catalogued", language: Spanish, message: "Something else", want: "Something else"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Translate(tt.language, tt.message); got != tt.want {
+				t.Errorf("Translate(%q, %q) = %q, want %q", tt.language, tt.message, got, tt.want)
+			}
+		})
+	}
+}
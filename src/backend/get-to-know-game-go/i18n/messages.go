@@ -0,0 +1,485 @@
+package i18n
+
+// messages maps each API message, written in English, to its translations
+var messages = map[string]map[string]string{
+	"A question pack with this name already exists": {
+		Romanian: "Există deja un pachet de întrebări cu acest nume",
+		Spanish:  "Ya existe un paquete de preguntas con este nombre",
+	},
+	"A question was answered more than once": {
+		Romanian: "O întrebare a primit mai multe răspunsuri",
+		Spanish:  "Una pregunta se respondió más de una vez",
+	},
+	"A question with this key already exists": {
+		Romanian: "Există deja o întrebare cu această cheie",
+		Spanish:  "Ya existe una pregunta con esta clave",
+	},
+	"A section with this name already exists": {
+		Romanian: "Există deja o secțiune cu acest nume",
+		Spanish:  "Ya existe una sección con este nombre",
+	},
+	"Access token does not belong to this player": {
+		Romanian: "Tokenul de acces nu aparține acestui jucător",
+		Spanish:  "El token de acceso no pertenece a este jugador",
+	},
+	"Access token required": {
+		Romanian: "Este necesar un token de acces",
+		Spanish:  "Se requiere un token de acceso",
+	},
+	"Add questions to the draft before publishing": {
+		Romanian: "Adăugați întrebări în ciornă înainte de publicare",
+		Spanish:  "Añade preguntas al borrador antes de publicarlo",
+	},
+	"All questions must be answered": {
+		Romanian: "Trebuie să răspundeți la toate întrebările",
+		Spanish:  "Hay que responder todas las preguntas",
+	},
+	"Another version was published at the same time, try again": {
+		Romanian: "Altă versiune a fost publicată în același timp, încercați din nou",
+		Spanish:  "Se publicó otra versión al mismo tiempo, inténtalo de nuevo",
+	},
+	"Answer to an unknown question": {
+		Romanian: "Răspuns la o întrebare necunoscută",
+		Spanish:  "Respuesta a una pregunta desconocida",
+	},
+	"Answers can't be changed once the results are in": {
+		Romanian: "Răspunsurile nu mai pot fi schimbate după ce rezultatele sunt gata",
+		Spanish:  "Las respuestas no se pueden cambiar una vez que hay resultados",
+	},
+	"Answers have already been submitted": {
+		Romanian: "Răspunsurile au fost deja trimise",
+		Spanish:  "Las respuestas ya se han enviado",
+	},
+	"Answers submitted successfully": {
+		Romanian: "Răspunsurile au fost trimise cu succes",
+		Spanish:  "Respuestas enviadas correctamente",
+	},
+	"At least one partner name is required": {
+		Romanian: "Este necesar cel puțin un nume de partener",
+		Spanish:  "Se requiere al menos un nombre de pareja",
+	},
+	"Broadcast has no invites": {
+		Romanian: "Transmisia nu are invitații",
+		Spanish:  "La difusión no tiene invitaciones",
+	},
+	"Broadcast not found": {
+		Romanian: "Transmisia nu a fost găsită",
+		Spanish:  "Difusión no encontrada",
+	},
+	"Dates must be YYYY-MM-DD or RFC 3339 times": {
+		Romanian: "Datele trebuie să fie în formatul YYYY-MM-DD sau RFC 3339",
+		Spanish:  "Las fechas deben tener el formato YYYY-MM-DD o RFC 3339",
+	},
+	"Each answer must include a valid prediction": {
+		Romanian: "Fiecare răspuns trebuie să includă o predicție validă",
+		Spanish:  "Cada respuesta debe incluir una predicción válida",
+	},
+	"Every translation needs question text": {
+		Romanian: "Fiecare traducere trebuie să aibă textul întrebării",
+		Spanish:  "Cada traducción necesita el texto de la pregunta",
+	},
+	"Failed to build results": {
+		Romanian: "Rezultatele nu au putut fi generate",
+		Spanish:  "No se pudieron generar los resultados",
+	},
+	"Failed to calculate compatibility score": {
+		Romanian: "Scorul de compatibilitate nu a putut fi calculat",
+		Spanish:  "No se pudo calcular la puntuación de compatibilidad",
+	},
+	"Failed to calculate leaderboard": {
+		Romanian: "Clasamentul nu a putut fi calculat",
+		Spanish:  "No se pudo calcular la clasificación",
+	},
+	"Failed to calculate matches": {
+		Romanian: "Potrivirile nu au putut fi calculate",
+		Spanish:  "No se pudieron calcular las coincidencias",
+	},
+	"Failed to cancel lobby ticket": {
+		Romanian: "Biletul din lobby nu a putut fi anulat",
+		Spanish:  "No se pudo cancelar el ticket de la sala de espera",
+	},
+	"Failed to compare partners": {
+		Romanian: "Partenerii nu au putut fi comparați",
+		Spanish:  "No se pudo comparar a los compañeros",
+	},
+	"Failed to compute question stats": {
+		Romanian: "Nu s-au putut calcula statisticile întrebărilor",
+		Spanish:  "No se pudieron calcular las estadísticas de las preguntas",
+	},
+	"Failed to create broadcast": {
+		Romanian: "Transmisia nu a putut fi creată",
+		Spanish:  "No se pudo crear la difusión",
+	},
+	"Failed to create join code": {
+		Romanian: "Codul de intrare nu a putut fi creat",
+		Spanish:  "No se pudo crear el código de acceso",
+	},
+	"Failed to create player": {
+		Romanian: "Jucătorul nu a putut fi creat",
+		Spanish:  "No se pudo crear el jugador",
+	},
+	"Failed to create player 1": {
+		Romanian: "Jucătorul 1 nu a putut fi creat",
+		Spanish:  "No se pudo crear el jugador 1",
+	},
+	"Failed to create player 2": {
+		Romanian: "Jucătorul 2 nu a putut fi creat",
+		Spanish:  "No se pudo crear el jugador 2",
+	},
+	"Failed to create question": {
+		Romanian: "Întrebarea nu a putut fi creată",
+		Spanish:  "No se pudo crear la pregunta",
+	},
+	"Failed to create question pack": {
+		Romanian: "Pachetul de întrebări nu a putut fi creat",
+		Spanish:  "No se pudo crear el paquete de preguntas",
+	},
+	"Failed to create room": {
+		Romanian: "Camera nu a putut fi creată",
+		Spanish:  "No se pudo crear la sala",
+	},
+	"Failed to create section": {
+		Romanian: "Secțiunea nu a putut fi creată",
+		Spanish:  "No se pudo crear la sección",
+	},
+	"Failed to create session": {
+		Romanian: "Sesiunea nu a putut fi creată",
+		Spanish:  "No se pudo crear la sesión",
+	},
+	"Failed to explain score": {
+		Romanian: "Scorul nu a putut fi explicat",
+		Spanish:  "No se pudo explicar la puntuación",
+	},
+	"Failed to export questions": {
+		Romanian: "Întrebările nu au putut fi exportate",
+		Spanish:  "No se pudieron exportar las preguntas",
+	},
+	"Failed to fetch pack versions": {
+		Romanian: "Nu s-au putut încărca versiunile pachetului",
+		Spanish:  "No se pudieron obtener las versiones del paquete",
+	},
+	"Failed to fetch player 1": {
+		Romanian: "Jucătorul 1 nu a putut fi încărcat",
+		Spanish:  "No se pudo obtener el jugador 1",
+	},
+	"Failed to fetch player 2": {
+		Romanian: "Jucătorul 2 nu a putut fi încărcat",
+		Spanish:  "No se pudo obtener el jugador 2",
+	},
+	"Failed to fetch question packs": {
+		Romanian: "Nu s-au putut încărca pachetele de întrebări",
+		Spanish:  "No se pudieron obtener los paquetes de preguntas",
+	},
+	"Failed to fetch questions": {
+		Romanian: "Întrebările nu au putut fi încărcate",
+		Spanish:  "No se pudieron obtener las preguntas",
+	},
+	"Failed to fetch score distributions": {
+		Romanian: "Distribuțiile scorurilor nu au putut fi încărcate",
+		Spanish:  "No se pudieron obtener las distribuciones de puntuación",
+	},
+	"Failed to fetch section weights": {
+		Romanian: "Ponderile secțiunilor nu au putut fi încărcate",
+		Spanish:  "No se pudieron obtener los pesos de las secciones",
+	},
+	"Failed to fetch sections": {
+		Romanian: "Secțiunile nu au putut fi încărcate",
+		Spanish:  "No se pudieron obtener las secciones",
+	},
+	"Failed to generate access token": {
+		Romanian: "Tokenul de acces nu a putut fi generat",
+		Spanish:  "No se pudo generar el token de acceso",
+	},
+	"Failed to import questions": {
+		Romanian: "Întrebările nu au putut fi importate",
+		Spanish:  "No se pudieron importar las preguntas",
+	},
+	"Failed to join lobby": {
+		Romanian: "Intrarea în lobby a eșuat",
+		Spanish:  "No se pudo entrar en la sala de espera",
+	},
+	"Failed to join room": {
+		Romanian: "Intrarea în cameră a eșuat",
+		Spanish:  "No se pudo entrar en la sala",
+	},
+	"Failed to join session": {
+		Romanian: "Intrarea în sesiune a eșuat",
+		Spanish:  "No se pudo unir a la sesión",
+	},
+	"Failed to preview question pack": {
+		Romanian: "Nu s-a putut previzualiza pachetul de întrebări",
+		Spanish:  "No se pudo previsualizar el paquete de preguntas",
+	},
+	"Failed to publish question pack": {
+		Romanian: "Nu s-a putut publica pachetul de întrebări",
+		Spanish:  "No se pudo publicar el paquete de preguntas",
+	},
+	"Failed to refresh lobby ticket": {
+		Romanian: "Biletul din lobby nu a putut fi actualizat",
+		Spanish:  "No se pudo actualizar el ticket de la sala de espera",
+	},
+	"Failed to roll back question pack": {
+		Romanian: "Nu s-a putut reveni la versiunea anterioară a pachetului",
+		Spanish:  "No se pudo revertir el paquete de preguntas",
+	},
+	"Failed to rotate access token": {
+		Romanian: "Tokenul de acces nu a putut fi schimbat",
+		Spanish:  "No se pudo renovar el token de acceso",
+	},
+	"Failed to submit answers": {
+		Romanian: "Răspunsurile nu au putut fi trimise",
+		Spanish:  "No se pudieron enviar las respuestas",
+	},
+	"Failed to update question": {
+		Romanian: "Întrebarea nu a putut fi actualizată",
+		Spanish:  "No se pudo actualizar la pregunta",
+	},
+	"Failed to update question pack": {
+		Romanian: "Pachetul de întrebări nu a putut fi actualizat",
+		Spanish:  "No se pudo actualizar el paquete de preguntas",
+	},
+	"Failed to update section": {
+		Romanian: "Secțiunea nu a putut fi actualizată",
+		Spanish:  "No se pudo actualizar la sección",
+	},
+	"Failed to update section weight": {
+		Romanian: "Ponderea secțiunii nu a putut fi actualizată",
+		Spanish:  "No se pudo actualizar el peso de la sección",
+	},
+	"Format must be csv, json or yaml": {
+		Romanian: "Formatul trebuie să fie csv, json sau yaml",
+		Spanish:  "El formato debe ser csv, json o yaml",
+	},
+	"Invalid access token": {
+		Romanian: "Token de acces invalid",
+		Spanish:  "Token de acceso no válido",
+	},
+	"Invalid audience": {
+		Romanian: "Public țintă invalid",
+		Spanish:  "Público no válido",
+	},
+	"Invalid game mode": {
+		Romanian: "Mod de joc invalid",
+		Spanish:  "Modo de juego no válido",
+	},
+	"Invalid question ID": {
+		Romanian: "ID de întrebare invalid",
+		Spanish:  "ID de pregunta no válido",
+	},
+	"Invalid rating": {
+		Romanian: "Clasificare invalidă",
+		Spanish:  "Clasificación no válida",
+	},
+	"Invalid request body": {
+		Romanian: "Corpul cererii este invalid",
+		Spanish:  "Cuerpo de la solicitud no válido",
+	},
+	"Invalid response to a question": {
+		Romanian: "Răspuns invalid la o întrebare",
+		Spanish:  "Respuesta no válida a una pregunta",
+	},
+	"Invalid response type": {
+		Romanian: "Tip de răspuns invalid",
+		Spanish:  "Tipo de respuesta no válido",
+	},
+	"Invalid section": {
+		Romanian: "Secțiune invalidă",
+		Spanish:  "Sección no válida",
+	},
+	"Invalid shuffle mode": {
+		Romanian: "Mod de amestecare invalid",
+		Spanish:  "Modo de mezcla no válido",
+	},
+	"Invalid version": {
+		Romanian: "Versiune invalidă",
+		Spanish:  "Versión no válida",
+	},
+	"Join code not found or expired": {
+		Romanian: "Codul de intrare nu a fost găsit sau a expirat",
+		Spanish:  "Código de acceso no encontrado o caducado",
+	},
+	"Lobby ticket is no longer waiting": {
+		Romanian: "Biletul din lobby nu mai este în așteptare",
+		Spanish:  "El ticket de la sala de espera ya no está en espera",
+	},
+	"Lobby ticket not found": {
+		Romanian: "Biletul din lobby nu a fost găsit",
+		Spanish:  "Ticket de la sala de espera no encontrado",
+	},
+	"Move or delete the section's questions first": {
+		Romanian: "Mutați sau ștergeți mai întâi întrebările secțiunii",
+		Spanish:  "Mueve o elimina primero las preguntas de la sección",
+	},
+	"Name is required": {
+		Romanian: "Numele este obligatoriu",
+		Spanish:  "El nombre es obligatorio",
+	},
+	"No questions match the audience and tags": {
+		Romanian: "Nicio întrebare nu corespunde publicului și etichetelor",
+		Spanish:  "Ninguna pregunta coincide con el público y las etiquetas",
+	},
+	"Only a positive or negative answer can be a dealbreaker": {
+		Romanian: "Doar un răspuns pozitiv sau negativ poate fi eliminatoriu",
+		Spanish:  "Solo una respuesta positiva o negativa puede ser decisiva",
+	},
+	"Pack name is required": {
+		Romanian: "Numele pachetului este obligatoriu",
+		Spanish:  "El nombre del paquete es obligatorio",
+	},
+	"Pack version not found": {
+		Romanian: "Versiunea pachetului nu a fost găsită",
+		Spanish:  "Versión del paquete no encontrada",
+	},
+	"Participant not found": {
+		Romanian: "Participantul nu a fost găsit",
+		Spanish:  "Participante no encontrado",
+	},
+	"Player 1 answers for a broadcast are submitted on the broadcast": {
+		Romanian: "Răspunsurile jucătorului 1 pentru o transmisie se trimit pe transmisie",
+		Spanish:  "Las respuestas del jugador 1 de una difusión se envían en la difusión",
+	},
+	"Player name is required": {
+		Romanian: "Numele jucătorului este obligatoriu",
+		Spanish:  "El nombre del jugador es obligatorio",
+	},
+	"Player not found": {
+		Romanian: "Jucătorul nu a fost găsit",
+		Spanish:  "Jugador no encontrado",
+	},
+	"Player updated successfully": {
+		Romanian: "Jucătorul a fost actualizat cu succes",
+		Spanish:  "Jugador actualizado correctamente",
+	},
+	"Question bank file could not be parsed": {
+		Romanian: "Fișierul cu întrebări nu a putut fi citit",
+		Spanish:  "No se pudo leer el archivo de preguntas",
+	},
+	"Question contains inappropriate language": {
+		Romanian: "Întrebarea conține limbaj nepotrivit",
+		Spanish:  "La pregunta contiene lenguaje inapropiado",
+	},
+	"Question not found": {
+		Romanian: "Întrebarea nu a fost găsită",
+		Spanish:  "Pregunta no encontrada",
+	},
+	"Question pack has not been published": {
+		Romanian: "Pachetul de întrebări nu a fost publicat",
+		Spanish:  "El paquete de preguntas no se ha publicado",
+	},
+	"Question pack not found": {
+		Romanian: "Pachetul de întrebări nu a fost găsit",
+		Spanish:  "Paquete de preguntas no encontrado",
+	},
+	"Question text is required": {
+		Romanian: "Textul întrebării este obligatoriu",
+		Spanish:  "El texto de la pregunta es obligatorio",
+	},
+	"Question text is too long": {
+		Romanian: "Textul întrebării este prea lung",
+		Spanish:  "El texto de la pregunta es demasiado largo",
+	},
+	"Question updated successfully": {
+		Romanian: "Întrebarea a fost actualizată cu succes",
+		Spanish:  "Pregunta actualizada correctamente",
+	},
+	"Room has expired": {
+		Romanian: "Camera a expirat",
+		Spanish:  "La sala ha caducado",
+	},
+	"Room is full": {
+		Romanian: "Camera este plină",
+		Spanish:  "La sala está llena",
+	},
+	"Room name is required": {
+		Romanian: "Numele camerei este obligatoriu",
+		Spanish:  "El nombre de la sala es obligatorio",
+	},
+	"Room not found": {
+		Romanian: "Camera nu a fost găsită",
+		Spanish:  "Sala no encontrada",
+	},
+	"Scores from this strategy can't be explained": {
+		Romanian: "Scorurile acestei strategii nu pot fi explicate",
+		Spanish:  "Las puntuaciones de esta estrategia no se pueden explicar",
+	},
+	"Section name is required": {
+		Romanian: "Numele secțiunii este obligatoriu",
+		Spanish:  "El nombre de la sección es obligatorio",
+	},
+	"Section name is too long": {
+		Romanian: "Numele secțiunii este prea lung",
+		Spanish:  "El nombre de la sección es demasiado largo",
+	},
+	"Section not found": {
+		Romanian: "Secțiunea nu a fost găsită",
+		Spanish:  "Sección no encontrada",
+	},
+	"Section weight not found": {
+		Romanian: "Ponderea secțiunii nu a fost găsită",
+		Spanish:  "Peso de la sección no encontrado",
+	},
+	"Session has expired": {
+		Romanian: "Sesiunea a expirat",
+		Spanish:  "La sesión ha caducado",
+	},
+	"Session is already full": {
+		Romanian: "Sesiunea este deja completă",
+		Spanish:  "La sesión ya está completa",
+	},
+	"Session is not complete yet": {
+		Romanian: "Sesiunea nu este încă finalizată",
+		Spanish:  "La sesión aún no está completa",
+	},
+	"Session is not in prediction mode": {
+		Romanian: "Sesiunea nu este în modul de predicție",
+		Spanish:  "La sesión no está en modo de predicción",
+	},
+	"Session not found": {
+		Romanian: "Sesiunea nu a fost găsită",
+		Spanish:  "Sesión no encontrada",
+	},
+	"Successfully joined session": {
+		Romanian: "Ați intrat cu succes în sesiune",
+		Spanish:  "Te has unido a la sesión correctamente",
+	},
+	"Too many custom questions": {
+		Romanian: "Prea multe întrebări personalizate",
+		Spanish:  "Demasiadas preguntas personalizadas",
+	},
+	"Too many dealbreakers": {
+		Romanian: "Prea multe răspunsuri eliminatorii",
+		Spanish:  "Demasiadas respuestas decisivas",
+	},
+	"Too many join code lookups, try again later": {
+		Romanian: "Prea multe căutări de coduri de intrare, încercați mai târziu",
+		Spanish:  "Demasiadas búsquedas de códigos de acceso, inténtalo más tarde",
+	},
+	"Too many partners": {
+		Romanian: "Prea mulți parteneri",
+		Spanish:  "Demasiadas parejas",
+	},
+	"Unknown scorer": {
+		Romanian: "Metodă de punctare necunoscută",
+		Spanish:  "Método de puntuación desconocido",
+	},
+	"Unsupported translation language": {
+		Romanian: "Limbă de traducere neacceptată",
+		Spanish:  "Idioma de traducción no admitido",
+	},
+	"Weight must be greater than 0 and at most 10": {
+		Romanian: "Ponderea trebuie să fie mai mare decât 0 și cel mult 10",
+		Spanish:  "El peso debe ser mayor que 0 y como máximo 10",
+	},
+	"onError must be fail or skip": {
+		Romanian: "onError trebuie să fie fail sau skip",
+		Spanish:  "onError debe ser fail o skip",
+	},
+}
+
+// Translate returns message in language, or message itself when it has no translation
+func Translate(language, message string) string {
+	if translated, exists := messages[message][language]; exists {
+		return translated
+	}
+	return message
+}
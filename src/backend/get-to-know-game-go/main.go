@@ -13,10 +13,14 @@ import (
 	"get-to-know-game-go/config"
 	"get-to-know-game-go/database"
 	"get-to-know-game-go/handlers"
+	"get-to-know-game-go/i18n"
+	"get-to-know-game-go/models"
 	"get-to-know-game-go/repositories"
+	"get-to-know-game-go/seeds"
 	"get-to-know-game-go/services"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
 )
 
 func main() {
@@ -34,25 +38,142 @@ func main() {
 	questionRepo := repositories.NewQuestionRepository(mongoDB.GetCollection("questions"))
 	playerRepo := repositories.NewPlayerRepository(mongoDB.GetCollection("players"))
 	sessionRepo := repositories.NewGameSessionRepository(mongoDB.GetCollection("sessions"))
+	joinCodeRepo := repositories.NewJoinCodeRepository(mongoDB.GetCollection("join_codes"))
+	sectionRepo := repositories.NewSectionRepository(mongoDB.GetCollection("sections"))
+	sectionWeightRepo := repositories.NewSectionWeightRepository(mongoDB.GetCollection("section_weights"))
+	answerFrequencyRepo := repositories.NewAnswerFrequencyRepository(mongoDB.GetCollection("answer_frequencies"))
+	scoreDistributionRepo := repositories.NewScoreDistributionRepository(mongoDB.GetCollection("score_distributions"))
+	broadcastRepo := repositories.NewBroadcastRepository(mongoDB.GetCollection("broadcasts"))
+	roomRepo := repositories.NewRoomRepository(mongoDB.GetCollection("rooms"))
+	lobbyRepo := repositories.NewLobbyRepository(mongoDB.GetCollection("lobby_tickets"))
+	seedPackRepo := repositories.NewSeedPackRepository(mongoDB.GetCollection("seed_packs"))
+	questionPackRepo := repositories.NewQuestionPackRepository(mongoDB.GetCollection("question_packs"))
+	packVersionRepo := repositories.NewPackVersionRepository(mongoDB.GetCollection("pack_versions"))
+
+	// The lobby queue is kept in process unless it has to be shared between instances
+	var lobbyStore repositories.LobbyStore = repositories.NewMemoryLobbyStore()
+	if cfg.LobbyStore == "mongo" {
+		lobbyStore = lobbyRepo
+	}
 
 	// Initialize services
-	compatibilityService := services.NewCompatibilityService()
-	databaseSeeder := services.NewDatabaseSeeder(questionRepo)
+	compatibilityService := services.NewCompatibilityService(services.NewDefaultScorerRegistry())
+	if !compatibilityService.HasScorer(cfg.ScoringStrategy) {
+		log.Fatalf("Unknown scoring strategy %q, available: %v", cfg.ScoringStrategy, compatibilityService.ScorerNames())
+	}
+	if !i18n.IsSupported(cfg.DefaultLanguage) {
+		log.Fatalf("Unsupported default language %q, available: %v", cfg.DefaultLanguage, i18n.SupportedLanguages())
+	}
+	for _, rating := range cfg.ExcludedRatings {
+		if !models.IsValidRating(rating) {
+			log.Fatalf("Unknown excluded rating %q, available: %v", rating, models.AllRatings())
+		}
+	}
+	predictionService := services.NewPredictionService()
+	answerFrequencyService := services.NewAnswerFrequencyService(answerFrequencyRepo, sessionRepo)
+	resultsService := services.NewResultsService(compatibilityService, predictionService, answerFrequencyService)
+	tokenService := services.NewTokenService()
+	contentValidator := services.NewContentValidator()
+	sectionService := services.NewSectionService(sectionRepo, questionRepo, sectionWeightRepo)
+	packService := services.NewPackService(questionPackRepo, packVersionRepo, questionRepo, sectionService)
+	audienceService := services.NewAudienceService(cfg.ExcludedRatings)
+	sessionQuestionService := services.NewSessionQuestionService(packService, audienceService, sectionService, contentValidator)
+	scoreDistributionService := services.NewScoreDistributionService(scoreDistributionRepo, sessionRepo)
+	sessionScoringService := services.NewSessionScoringService(sessionRepo, sessionQuestionService, compatibilityService, scoreDistributionService, answerFrequencyService, cfg.ScoringStrategy, cfg.DealbreakerScoreCap)
+	joinCodeService := services.NewJoinCodeService(joinCodeRepo)
+	broadcastService := services.NewBroadcastService(broadcastRepo, sessionRepo, playerRepo, sessionQuestionService, sessionScoringService, compatibilityService, tokenService, joinCodeService, cfg.SessionTTL)
+	roomService := services.NewRoomService(roomRepo, questionRepo, sectionService, packService, audienceService, compatibilityService, sessionScoringService, tokenService, joinCodeService, cfg.SessionTTL)
+	lobbyService := services.NewLobbyService(lobbyStore, playerRepo, sessionRepo, packService, tokenService, cfg.LobbyTimeout, cfg.SessionTTL)
+	questionBankService := services.NewQuestionBankService(questionRepo, sectionService, packService)
+	localizationService := services.NewLocalizationService(sectionRepo)
+	questionStatsService := services.NewQuestionStatsService(questionRepo, sessionRepo, sectionService)
+	databaseSeeder := services.NewDatabaseSeeder(questionRepo, seedPackRepo, sectionService, packService, seeds.Packs, cfg.SeedDir)
 
-	// Seed database
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	if err := databaseSeeder.SeedQuestions(ctx); err != nil {
+
+	// Ensure indexes
+	if err := joinCodeRepo.EnsureIndexes(ctx); err != nil {
+		log.Printf("Failed to create join code indexes: %v", err)
+	}
+	if err := questionRepo.EnsureIndexes(ctx); err != nil {
+		log.Printf("Failed to create question indexes: %v", err)
+	}
+	if err := sectionRepo.EnsureIndexes(ctx); err != nil {
+		log.Printf("Failed to create section indexes: %v", err)
+	}
+	if err := questionPackRepo.EnsureIndexes(ctx); err != nil {
+		log.Printf("Failed to create question pack indexes: %v", err)
+	}
+	if err := packVersionRepo.EnsureIndexes(ctx); err != nil {
+		log.Printf("Failed to create pack version indexes: %v", err)
+	}
+	if cfg.LobbyStore == "mongo" {
+		if err := lobbyRepo.EnsureIndexes(ctx); err != nil {
+			log.Printf("Failed to create lobby indexes: %v", err)
+		}
+	}
+
+	// Link questions created before sections existed to sections built from their names
+	if err := sectionService.MigrateSections(ctx); err != nil {
+		log.Printf("Failed to migrate sections: %v", err)
+	}
+	// Move section weights kept by name onto their sections
+	if err := sectionService.MigrateSectionWeights(ctx); err != nil {
+		log.Printf("Failed to migrate section weights: %v", err)
+	}
+
+	// Publish the existing question bank as the first version of the default pack
+	if err := packService.EnsureDefaultPack(ctx); err != nil {
+		log.Printf("Failed to create default question pack: %v", err)
+	}
+
+	// Seed database with the seed packs not applied yet, publishing the default pack again
+	if _, err := databaseSeeder.SeedQuestions(ctx, false); err != nil {
 		log.Printf("Failed to seed database: %v", err)
 	}
 
+	// Subcommands such as export-questions run instead of the server
+	if len(os.Args) > 1 {
+		if err := runCommand(context.Background(), os.Args[1:], commandServices{
+			questionBank: questionBankService,
+			seeder:       databaseSeeder,
+		}); err != nil {
+			log.Fatalf("%s failed: %v", os.Args[1], err)
+		}
+		return
+	}
+
+	// Build score distributions and answer frequencies from sessions completed before they were
+	// kept. This finishes before the server listens, so no session is scored meanwhile.
+	backfillCtx, backfillCancel := context.WithTimeout(context.Background(), time.Minute)
+	if err := scoreDistributionService.Backfill(backfillCtx); err != nil {
+		log.Printf("Failed to backfill score distributions: %v", err)
+	}
+	if err := answerFrequencyService.Backfill(backfillCtx); err != nil {
+		log.Printf("Failed to backfill answer frequencies: %v", err)
+	}
+	backfillCancel()
+
 	// Initialize handlers
-	questionsHandler := handlers.NewQuestionsHandler(questionRepo)
+	questionsHandler := handlers.NewQuestionsHandler(questionRepo, sectionService, questionBankService, localizationService, packService, audienceService)
+	sectionsHandler := handlers.NewSectionsHandler(sectionService, localizationService)
+	packsHandler := handlers.NewPacksHandler(packService)
 	playersHandler := handlers.NewPlayersHandler(playerRepo)
-	sessionsHandler := handlers.NewSessionsHandler(sessionRepo, playerRepo, compatibilityService)
+	sessionsHandler := handlers.NewSessionsHandler(sessionRepo, playerRepo, sessionQuestionService, sessionScoringService, predictionService, resultsService, scoreDistributionService, tokenService, joinCodeService, localizationService, cfg.SessionTTL)
+	joinHandler := handlers.NewJoinHandler(joinCodeService)
+	lobbyHandler := handlers.NewLobbyHandler(lobbyService, tokenService)
+	roomsHandler := handlers.NewRoomsHandler(roomService, tokenService, localizationService)
+	broadcastsHandler := handlers.NewBroadcastsHandler(broadcastService, tokenService)
+	statsHandler := handlers.NewStatsHandler(scoreDistributionService, questionStatsService, localizationService)
 
 	// Setup Fiber app
-	app := fiber.New()
+	// Behind a reverse proxy, client addresses come from its header, but only on requests it forwarded
+	app := fiber.New(fiber.Config{
+		ProxyHeader:             cfg.ProxyHeader,
+		EnableTrustedProxyCheck: cfg.ProxyHeader != "",
+		TrustedProxies:          cfg.TrustedProxies,
+	})
 
 	// Configure CORS
 	app.Use(func(c *fiber.Ctx) error {
@@ -103,17 +224,47 @@ func main() {
 		return c.Next()
 	})
 
+	// Negotiate the language of every request and translate API messages into it
+	app.Use(handlers.Localize(cfg.DefaultLanguage))
+
 	// API routes
 	api := app.Group("/api")
 
 	// Questions routes
 	questions := api.Group("/questions")
 	questions.Get("", questionsHandler.GetQuestions)
+	questions.Get("/export", questionsHandler.ExportQuestions)
+	questions.Post("/import", questionsHandler.ImportQuestions)
+	questions.Get("/stats", statsHandler.GetQuestionsStats)
+	questions.Get("/sections/weights", questionsHandler.GetSectionWeights)
+	questions.Put("/sections/:section/weight", questionsHandler.UpdateSectionWeight)
+	questions.Delete("/sections/:section/weight", questionsHandler.DeleteSectionWeight)
 	questions.Get("/:id", questionsHandler.GetQuestion)
+	questions.Get("/:id/stats", statsHandler.GetQuestionStats)
 	questions.Post("", questionsHandler.CreateQuestion)
 	questions.Put("/:id", questionsHandler.UpdateQuestion)
 	questions.Delete("/:id", questionsHandler.DeleteQuestion)
 
+	// Sections routes
+	sections := api.Group("/sections")
+	sections.Get("", sectionsHandler.GetSections)
+	sections.Get("/:id", sectionsHandler.GetSection)
+	sections.Post("", sectionsHandler.CreateSection)
+	sections.Put("/:id", sectionsHandler.UpdateSection)
+	sections.Delete("/:id", sectionsHandler.DeleteSection)
+
+	// Question packs routes
+	packs := api.Group("/packs")
+	packs.Get("", packsHandler.GetPacks)
+	packs.Post("", packsHandler.CreatePack)
+	packs.Get("/:packId", packsHandler.GetPack)
+	packs.Put("/:packId", packsHandler.UpdatePack)
+	packs.Get("/:packId/preview", packsHandler.PreviewPack)
+	packs.Post("/:packId/publish", packsHandler.PublishPack)
+	packs.Post("/:packId/rollback", packsHandler.RollbackPack)
+	packs.Get("/:packId/versions", packsHandler.GetPackVersions)
+	packs.Get("/:packId/versions/:version", packsHandler.GetPackVersion)
+
 	// Players routes
 	players := api.Group("/players")
 	players.Post("", playersHandler.CreatePlayer)
@@ -125,9 +276,50 @@ func main() {
 	sessions := api.Group("/sessions")
 	sessions.Post("", sessionsHandler.CreateSession)
 	sessions.Get("/:sessionId", sessionsHandler.GetSession)
+	sessions.Get("/:sessionId/questions", sessionsHandler.GetSessionQuestions)
 	sessions.Post("/:sessionId/join", sessionsHandler.JoinSession)
 	sessions.Put("/:sessionId/answers", sessionsHandler.SubmitAnswers)
+	sessions.Get("/:sessionId/results", sessionsHandler.GetResults)
+	sessions.Get("/:sessionId/score/explain", sessionsHandler.ExplainScore)
+	sessions.Get("/:sessionId/predictions", sessionsHandler.GetPredictions)
+	sessions.Post("/:sessionId/token/rotate", sessionsHandler.RotateToken)
 	sessions.Delete("/:sessionId", sessionsHandler.DeleteSession)
+
+	// Broadcasts routes
+	broadcasts := api.Group("/broadcasts")
+	broadcasts.Post("", broadcastsHandler.CreateBroadcast)
+	broadcasts.Put("/:broadcastId/answers", broadcastsHandler.SubmitBroadcastAnswers)
+	broadcasts.Get("/:broadcastId/comparison", broadcastsHandler.GetComparison)
+
+	// Stats routes
+	stats := api.Group("/stats")
+	stats.Get("/score-distribution", statsHandler.GetScoreDistribution)
+
+	// Join code routes, rate limited per client to stop brute-force guessing
+	api.Get("/join/:code", limiter.New(limiter.Config{
+		Max:          cfg.JoinCodeRateLimit,
+		Expiration:   time.Minute,
+		KeyGenerator: handlers.ClientIP,
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": "Too many join code lookups, try again later"})
+		},
+	}), joinHandler.ResolveJoinCode)
+
+	// Lobby routes
+	lobby := api.Group("/lobby")
+	lobby.Post("/join", lobbyHandler.JoinLobby)
+	lobby.Get("/:ticketId", lobbyHandler.GetTicket)
+	lobby.Delete("/:ticketId", lobbyHandler.CancelTicket)
+
+	// Rooms routes
+	rooms := api.Group("/rooms")
+	rooms.Post("", roomsHandler.CreateRoom)
+	rooms.Get("/:roomId", roomsHandler.GetRoom)
+	rooms.Get("/:roomId/questions", roomsHandler.GetRoomQuestions)
+	rooms.Get("/:roomId/leaderboard", roomsHandler.GetLeaderboard)
+	rooms.Post("/:roomId/participants", roomsHandler.JoinRoom)
+	rooms.Put("/:roomId/participants/:participantId/answers", roomsHandler.SubmitRoomAnswers)
+	rooms.Get("/:roomId/participants/:participantId/matches", roomsHandler.GetParticipantMatches)
 	
 	log.Println("Routes registered successfully")
 
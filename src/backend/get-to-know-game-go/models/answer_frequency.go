@@ -0,0 +1,46 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AnswerFrequency counts how often each response was given to a question across completed sessions
+type AnswerFrequency struct {
+	QuestionID primitive.ObjectID `bson:"_id" json:"questionId"`
+	Counts     map[string]int     `bson:"counts" json:"counts"`
+	Total      int                `bson:"total" json:"total"`
+	UpdatedAt  time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// AnswerCount is the number of times one response was given to a question
+type AnswerCount struct {
+	QuestionID primitive.ObjectID
+	Response   string
+	Count      int
+}
+
+// Share returns the fraction of answers to the question that were response
+func (f AnswerFrequency) Share(response string) float64 {
+	if f.Total == 0 {
+		return 0
+	}
+	return float64(f.Counts[response]) / float64(f.Total)
+}
+
+// Rarity returns the smoothed inverse document frequency of response: 1 for a response
+// everyone gives or when nothing has been recorded yet, growing as the response gets rarer
+func (f AnswerFrequency) Rarity(response string) float64 {
+	return math.Log(float64(f.Total+1)/float64(f.Counts[response]+1)) + 1
+}
+
+// RareSharedAnswer is a response both players gave that few other players give
+type RareSharedAnswer struct {
+	QuestionID   string `json:"questionId"`
+	QuestionText string `json:"questionText"`
+	Response     string `json:"response"`
+	// Share is the percentage of all players who gave the same response
+	Share int `json:"share"`
+}
@@ -0,0 +1,99 @@
+package models
+
+import "strconv"
+
+// Answer scale constants for questions. Response values are unique across scales,
+// so a response on its own tells which scale it belongs to.
+const (
+	// ScaleYayNay is the original three-way "Yay!", "Nay!", "I don't care!" scale
+	ScaleYayNay = "yay-nay"
+	// ScaleLikert5 is a 5-point agreement scale answered "1" (strongly disagree) to "5" (strongly agree)
+	ScaleLikert5 = "likert-5"
+	// ScaleYesNo is a plain "Yes" or "No" scale
+	ScaleYesNo = "yes-no"
+)
+
+// Yes/no responses
+const (
+	Yes = "Yes"
+	No  = "No"
+)
+
+// likertPoints is the number of points on the Likert scale
+const likertPoints = 5
+
+// AllAnswerScales returns all possible answer scales
+func AllAnswerScales() []string {
+	return []string{ScaleYayNay, ScaleLikert5, ScaleYesNo}
+}
+
+// IsValidAnswerScale reports whether scale is one of the possible answer scales
+func IsValidAnswerScale(scale string) bool {
+	for _, s := range AllAnswerScales() {
+		if s == scale {
+			return true
+		}
+	}
+	return false
+}
+
+// ScaleResponses returns the responses allowed on a scale, defaulting to the yay-nay scale
+func ScaleResponses(scale string) []string {
+	switch scale {
+	case ScaleLikert5:
+		responses := make([]string, 0, likertPoints)
+		for point := 1; point <= likertPoints; point++ {
+			responses = append(responses, strconv.Itoa(point))
+		}
+		return responses
+	case ScaleYesNo:
+		return []string{Yes, No}
+	default:
+		return AllResponseTypes()
+	}
+}
+
+// IsValidScaleResponse reports whether response is allowed on scale
+func IsValidScaleResponse(scale, response string) bool {
+	for _, r := range ScaleResponses(scale) {
+		if r == response {
+			return true
+		}
+	}
+	return false
+}
+
+// LikertPoint returns the position of a Likert response, from 1 to 5
+func LikertPoint(response string) (int, bool) {
+	point, err := strconv.Atoi(response)
+	if err != nil || point < 1 || point > likertPoints {
+		return 0, false
+	}
+	return point, true
+}
+
+// LikertCredit returns the partial credit between two Likert points: 1 when equal, 0 at opposite ends
+func LikertCredit(point1, point2 int) float64 {
+	distance := point1 - point2
+	if distance < 0 {
+		distance = -distance
+	}
+	return 1 - float64(distance)/float64(likertPoints-1)
+}
+
+// IsPositiveResponse reports whether response expresses liking: "Yay!", "Yes" or agreeing on the Likert scale
+func IsPositiveResponse(response string) bool {
+	if point, ok := LikertPoint(response); ok {
+		return point > (likertPoints+1)/2
+	}
+	return response == Yay || response == Yes
+}
+
+// IsNegativeResponse reports whether response expresses dislike: "Nay!", "No" or disagreeing on the Likert scale.
+// Negative responses are never revealed to the partner.
+func IsNegativeResponse(response string) bool {
+	if point, ok := LikertPoint(response); ok {
+		return point < (likertPoints+1)/2
+	}
+	return response == Nay || response == No
+}
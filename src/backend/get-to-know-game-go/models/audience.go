@@ -0,0 +1,100 @@
+package models
+
+import "strings"
+
+// Audience rating constants for questions, from the widest audience to the narrowest
+const (
+	// RatingEveryone suits every player; questions without a rating count as rated for everyone
+	RatingEveryone = "everyone"
+	// RatingTeen suits players aged 13 and over
+	RatingTeen = "teen"
+	// RatingAdult is only for adults
+	RatingAdult = "adult"
+)
+
+// AllRatings returns all audience ratings, from the widest audience to the narrowest
+func AllRatings() []string {
+	return []string{RatingEveryone, RatingTeen, RatingAdult}
+}
+
+// IsValidRating reports whether rating is one of the audience ratings
+func IsValidRating(rating string) bool {
+	return ratingLevel(rating) >= 0
+}
+
+// ratingLevel ranks a rating by how narrow its audience is, or returns -1 for an unknown rating
+func ratingLevel(rating string) int {
+	for level, r := range AllRatings() {
+		if r == rating {
+			return level
+		}
+	}
+	return -1
+}
+
+// AudienceRating returns the question's audience rating, defaulting to everyone for unrated questions
+func (q Question) AudienceRating() string {
+	if q.Rating == "" {
+		return RatingEveryone
+	}
+	return q.Rating
+}
+
+// HasAnyTag reports whether the question carries at least one of tags
+func (q Question) HasAnyTag(tags []string) bool {
+	for _, tag := range tags {
+		for _, own := range q.Tags {
+			if own == tag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// QuestionFilter selects the questions suitable for an audience and, optionally, on some topics
+type QuestionFilter struct {
+	// Audience is the narrowest rating served; empty serves every rating
+	Audience string `bson:"audience,omitempty" json:"audience,omitempty"`
+	// Tags keeps the questions carrying at least one of them; empty keeps every question
+	Tags []string `bson:"tags,omitempty" json:"tags,omitempty"`
+}
+
+// Matches reports whether question is rated for the filter's audience and carries one of its tags
+func (f QuestionFilter) Matches(question Question) bool {
+	if f.Audience != "" && ratingLevel(question.AudienceRating()) > ratingLevel(f.Audience) {
+		return false
+	}
+	return len(f.Tags) == 0 || question.HasAnyTag(f.Tags)
+}
+
+// IsEmpty reports whether the filter keeps every question
+func (f QuestionFilter) IsEmpty() bool {
+	return f.Audience == "" && len(f.Tags) == 0
+}
+
+// NormalizeTags lowercases tags and joins their words with hyphens, dropping empty and repeated tags
+func NormalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = slug(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// ParseTags splits a comma-separated list of tags, as given in a query string
+func ParseTags(list string) []string {
+	if strings.TrimSpace(list) == "" {
+		return nil
+	}
+	return NormalizeTags(strings.Split(list, ","))
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Broadcast is a game template where player 1 answers once and is compared
+// against several invited partners, each in their own game session
+type Broadcast struct {
+	ID               primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Player1ID        primitive.ObjectID `bson:"player1Id" json:"player1Id"`
+	Player1TokenHash string             `bson:"player1TokenHash" json:"-"`
+	Player1Answers   []PlayerAnswer     `bson:"player1Answers" json:"-"`
+	Invites          []BroadcastInvite  `bson:"invites" json:"invites"`
+	CreatedAt        time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// BroadcastInvite is the game session issued to one named partner
+type BroadcastInvite struct {
+	PartnerName string             `bson:"partnerName" json:"partnerName"`
+	SessionID   primitive.ObjectID `bson:"sessionId" json:"sessionId"`
+	JoinCode    string             `bson:"joinCode" json:"joinCode"`
+}
+
+// BroadcastComparison ranks one partner of a broadcast against player 1
+type BroadcastComparison struct {
+	PartnerName        string         `json:"partnerName"`
+	SessionID          string         `json:"sessionId"`
+	Status             string         `json:"status"`
+	CompatibilityScore *int           `json:"compatibilityScore,omitempty"`
+	Emoji              string         `json:"emoji,omitempty"`
+	Sections           []SectionScore `json:"sections,omitempty"`
+}
+
+// BroadcastPartnerStatus constants for broadcast comparisons
+const (
+	BroadcastPartnerInvited  = "invited"
+	BroadcastPartnerJoined   = "joined"
+	BroadcastPartnerAnswered = "answered"
+)
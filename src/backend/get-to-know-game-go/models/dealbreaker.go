@@ -0,0 +1,26 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// MaxDealbreakers is how many answers a player can flag as dealbreakers in one session
+const MaxDealbreakers = 3
+
+// TriggeredDealbreaker is an answer flagged as a dealbreaker that the partner answered the opposite way
+type TriggeredDealbreaker struct {
+	QuestionID primitive.ObjectID `bson:"questionId" json:"questionId"`
+	// PlayerID is the player who flagged the answer
+	PlayerID primitive.ObjectID `bson:"playerId" json:"playerId"`
+}
+
+// DealbreakerResult is a triggered dealbreaker as shown in the results
+type DealbreakerResult struct {
+	QuestionID   string `json:"questionId"`
+	QuestionText string `json:"questionText"`
+	FlaggedBy    string `json:"flaggedBy"`
+}
+
+// IsOppositeResponse reports whether two responses are on opposite sides, one positive and one negative
+func IsOppositeResponse(response1, response2 string) bool {
+	return (IsPositiveResponse(response1) && IsNegativeResponse(response2)) ||
+		(IsNegativeResponse(response1) && IsPositiveResponse(response2))
+}
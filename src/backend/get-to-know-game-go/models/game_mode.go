@@ -0,0 +1,22 @@
+package models
+
+// GameMode constants for game sessions
+const (
+	GameModeClassic    = "classic"
+	GameModePrediction = "prediction"
+)
+
+// AllGameModes returns all supported game modes
+func AllGameModes() []string {
+	return []string{GameModeClassic, GameModePrediction}
+}
+
+// IsValidGameMode reports whether mode is a supported game mode
+func IsValidGameMode(mode string) bool {
+	for _, m := range AllGameModes() {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
@@ -1,14 +1,73 @@
 package models
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
 
 // GameSession represents a game session between two players
 type GameSession struct {
-	ID                 primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	Player1ID          primitive.ObjectID `bson:"player1Id" json:"player1Id"`
+	ID                 primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	Mode               string              `bson:"mode,omitempty" json:"mode,omitempty"`
+	Player1ID          primitive.ObjectID  `bson:"player1Id" json:"player1Id"`
 	Player2ID          *primitive.ObjectID `bson:"player2Id,omitempty" json:"player2Id,omitempty"`
-	Player1Answers     []PlayerAnswer     `bson:"player1Answers" json:"player1Answers"`
-	Player2Answers     *[]PlayerAnswer    `bson:"player2Answers,omitempty" json:"player2Answers,omitempty"`
-	CompatibilityScore *int               `bson:"compatibilityScore,omitempty" json:"compatibilityScore,omitempty"`
-	Player2Name        *string            `bson:"player2Name,omitempty" json:"player2Name,omitempty"`
+	Player1Answers     []PlayerAnswer      `bson:"player1Answers" json:"player1Answers"`
+	Player2Answers     *[]PlayerAnswer     `bson:"player2Answers,omitempty" json:"player2Answers,omitempty"`
+	CompatibilityScore *int                `bson:"compatibilityScore,omitempty" json:"compatibilityScore,omitempty"`
+	ScoringStrategy    string              `bson:"scoringStrategy,omitempty" json:"scoringStrategy,omitempty"`
+	ScoringVersion     int                 `bson:"scoringVersion,omitempty" json:"scoringVersion,omitempty"`
+	Player2Name        *string             `bson:"player2Name,omitempty" json:"player2Name,omitempty"`
+	Player1TokenHash   string              `bson:"player1TokenHash,omitempty" json:"-"`
+	Player2TokenHash   string              `bson:"player2TokenHash,omitempty" json:"-"`
+	JoinCode           string              `bson:"joinCode,omitempty" json:"joinCode,omitempty"`
+	CreatedAt          *time.Time          `bson:"createdAt,omitempty" json:"createdAt,omitempty"`
+	ExpiresAt          *time.Time          `bson:"expiresAt,omitempty" json:"expiresAt,omitempty"`
+	// CustomQuestions are written by player 1 and only exist on this session
+	CustomQuestions []Question `bson:"customQuestions,omitempty" json:"customQuestions,omitempty"`
+	// BroadcastID links sessions created from one broadcast, where player 1 answers once for all partners
+	BroadcastID *primitive.ObjectID `bson:"broadcastId,omitempty" json:"broadcastId,omitempty"`
+	// SectionScores breaks the compatibility down by question section
+	SectionScores []SectionScore `bson:"sectionScores,omitempty" json:"sectionScores,omitempty"`
+	// Dealbreakers lists flagged answers the partner contradicted; ScoreCapped reports whether they lowered the score
+	Dealbreakers []TriggeredDealbreaker `bson:"dealbreakers,omitempty" json:"dealbreakers,omitempty"`
+	ScoreCapped  bool                   `bson:"scoreCapped,omitempty" json:"scoreCapped,omitempty"`
+	// QuestionSetKey identifies the bank questions the session was scored on, for score percentiles
+	QuestionSetKey string `bson:"questionSetKey,omitempty" json:"questionSetKey,omitempty"`
+	// QuestionWeights snapshots the effective weight of each question ID when the session was scored
+	QuestionWeights map[string]float64 `bson:"questionWeights,omitempty" json:"questionWeights,omitempty"`
+	// PackID and PackVersion pin the published question pack version the session plays;
+	// sessions created before packs existed play the default pack's draft
+	PackID      primitive.ObjectID `bson:"packId,omitempty" json:"packId,omitempty"`
+	PackVersion int                `bson:"packVersion,omitempty" json:"packVersion,omitempty"`
+	// Audience and Tags restrict the bank questions played to the filter given when the session was created
+	Audience string   `bson:"audience,omitempty" json:"audience,omitempty"`
+	Tags     []string `bson:"tags,omitempty" json:"tags,omitempty"`
+	// Shuffle is the shuffle mode the questions are served in, derived from ShuffleSeed so both
+	// players get the same order; sessions created before shuffling serve them unshuffled
+	Shuffle     string `bson:"shuffle,omitempty" json:"shuffle,omitempty"`
+	ShuffleSeed int64  `bson:"shuffleSeed,omitempty" json:"-"`
+}
+
+// QuestionFilter returns the audience and tag filter the session's bank questions were chosen with
+func (s GameSession) QuestionFilter() QuestionFilter {
+	return QuestionFilter{Audience: s.Audience, Tags: s.Tags}
+}
+
+// GameMode returns the session's game mode, defaulting to classic for older sessions
+func (s GameSession) GameMode() string {
+	if s.Mode == "" {
+		return GameModeClassic
+	}
+	return s.Mode
+}
+
+// IsComplete reports whether both players have answered and the score has been calculated
+func (s GameSession) IsComplete() bool {
+	return s.Player2ID != nil && s.Player2Answers != nil && len(*s.Player2Answers) > 0 && s.CompatibilityScore != nil
+}
+
+// IsExpired reports whether the session can no longer be joined
+func (s GameSession) IsExpired(now time.Time) bool {
+	return s.ExpiresAt != nil && now.After(*s.ExpiresAt)
 }
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// JoinCode maps a short human-friendly code to a game session or a party room
+type JoinCode struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Code      string             `bson:"code" json:"code"`
+	SessionID primitive.ObjectID `bson:"sessionId,omitempty" json:"sessionId,omitempty"`
+	RoomID    primitive.ObjectID `bson:"roomId,omitempty" json:"roomId,omitempty"`
+	ExpiresAt time.Time          `bson:"expiresAt" json:"expiresAt"`
+}
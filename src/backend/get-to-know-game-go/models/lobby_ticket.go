@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LobbyStatus constants for lobby tickets
+const (
+	LobbyStatusWaiting   = "waiting"
+	LobbyStatusPairing   = "pairing"
+	LobbyStatusMatched   = "matched"
+	LobbyStatusCancelled = "cancelled"
+	LobbyStatusExpired   = "expired"
+)
+
+// LobbyTicket represents a player waiting in the matchmaking lobby
+type LobbyTicket struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	PlayerName string             `bson:"playerName" json:"playerName"`
+	Group      string             `bson:"group" json:"group,omitempty"`
+	AgeBand    string             `bson:"ageBand" json:"ageBand,omitempty"`
+	// ClientID identifies the device that queued the ticket so it is never paired with itself;
+	// without one from the client it is derived from the client's address
+	ClientID  string              `bson:"clientId,omitempty" json:"-"`
+	TokenHash string              `bson:"tokenHash" json:"-"`
+	Status    string              `bson:"status" json:"status"`
+	SessionID *primitive.ObjectID `bson:"sessionId,omitempty" json:"sessionId,omitempty"`
+	PlayerID  *primitive.ObjectID `bson:"playerId,omitempty" json:"playerId,omitempty"`
+	// PlayerNumber is 1 or 2 depending on which side of the matched session the player is on
+	PlayerNumber int       `bson:"playerNumber,omitempty" json:"playerNumber,omitempty"`
+	CreatedAt    time.Time `bson:"createdAt" json:"createdAt"`
+	ExpiresAt    time.Time `bson:"expiresAt" json:"expiresAt"`
+}
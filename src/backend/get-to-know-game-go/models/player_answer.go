@@ -6,4 +6,8 @@ import "go.mongodb.org/mongo-driver/bson/primitive"
 type PlayerAnswer struct {
 	QuestionID primitive.ObjectID `bson:"questionId" json:"questionId"`
 	Response   string             `bson:"response" json:"response"`
+	// Prediction is the player's guess of the partner's response (prediction mode only)
+	Prediction string `bson:"prediction,omitempty" json:"prediction,omitempty"`
+	// Dealbreaker marks an answer the partner must not contradict, capping the score if they do
+	Dealbreaker bool `bson:"dealbreaker,omitempty" json:"dealbreaker,omitempty"`
 }
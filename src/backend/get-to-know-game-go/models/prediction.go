@@ -0,0 +1,20 @@
+package models
+
+// PredictionOutcome describes one guess a player made about their partner's answer.
+// In player-facing views Actual is only set for correct guesses, and guesses of a negative
+// response are left out, so a partner's "Nay!" can't be inferred.
+type PredictionOutcome struct {
+	QuestionID string `json:"questionId"`
+	Predicted  string `json:"predicted"`
+	Actual     string `json:"actual,omitempty"`
+	Correct    bool   `json:"correct"`
+}
+
+// PredictionAccuracy summarizes how well a player predicted their partner's answers
+type PredictionAccuracy struct {
+	PlayerID  string              `json:"playerId"`
+	Correct   int                 `json:"correct"`
+	Total     int                 `json:"total"`
+	Score     int                 `json:"score"`
+	Breakdown []PredictionOutcome `json:"breakdown"`
+}
@@ -7,4 +7,32 @@ type Question struct {
 	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	Section      string             `bson:"section" json:"section"`
 	QuestionText string             `bson:"questionText" json:"questionText"`
+	// Key identifies the question across imports, exports and seed packs
+	Key string `bson:"key,omitempty" json:"key,omitempty"`
+	// SectionID references the question's section; Section keeps its name for display and grouping
+	SectionID primitive.ObjectID `bson:"sectionId,omitempty" json:"sectionId,omitempty"`
+	// Order is the question's position within its section
+	Order int `bson:"order" json:"order"`
+	// ResponseType is the answer scale of the question; unset means ScaleYayNay
+	ResponseType string `bson:"responseType,omitempty" json:"responseType,omitempty"`
+	// Weight scales how much the question counts in weighted scoring; unset means DefaultWeight
+	Weight *float64 `bson:"weight,omitempty" json:"weight,omitempty"`
+	// SeedChecksum fingerprints the question as last written by a seed pack; a mismatch means an admin edited it
+	SeedChecksum string `bson:"seedChecksum,omitempty" json:"-"`
+	// Translations maps a language code to the question's text in that language
+	Translations map[string]QuestionTranslation `bson:"translations,omitempty" json:"translations,omitempty"`
+	// PackID is the question pack whose draft the question belongs to; unset means the default pack
+	PackID primitive.ObjectID `bson:"packId,omitempty" json:"packId,omitempty"`
+	// Tags are the question's topics, such as "food" or "travel", for filtering
+	Tags []string `bson:"tags,omitempty" json:"tags,omitempty"`
+	// Rating is the narrowest audience the question suits; unset means RatingEveryone
+	Rating string `bson:"rating,omitempty" json:"rating,omitempty"`
+}
+
+// Scale returns the question's answer scale, defaulting to the yay-nay scale for older questions
+func (q Question) Scale() string {
+	if q.ResponseType == "" {
+		return ScaleYayNay
+	}
+	return q.ResponseType
 }
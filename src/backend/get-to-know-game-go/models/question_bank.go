@@ -0,0 +1,80 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Question bank file formats
+const (
+	QuestionBankCSV  = "csv"
+	QuestionBankJSON = "json"
+	QuestionBankYAML = "yaml"
+)
+
+// QuestionRecord is a question as written in an imported or exported question bank file
+type QuestionRecord struct {
+	Key          string   `json:"key,omitempty" yaml:"key,omitempty"`
+	Section      string   `json:"section" yaml:"section"`
+	QuestionText string   `json:"questionText" yaml:"questionText"`
+	ResponseType string   `json:"responseType,omitempty" yaml:"responseType,omitempty"`
+	Weight       *float64 `json:"weight,omitempty" yaml:"weight,omitempty"`
+	Order        int      `json:"order" yaml:"order"`
+	Tags         []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Rating       string   `json:"rating,omitempty" yaml:"rating,omitempty"`
+	// Pack is the key of the question pack whose draft the question belongs to; empty means the default pack
+	Pack string `json:"pack,omitempty" yaml:"pack,omitempty"`
+	// Translations maps a language code to the question's text in that language
+	Translations map[string]QuestionTranslation `json:"translations,omitempty" yaml:"translations,omitempty"`
+}
+
+// ImportOptions controls how a question bank is imported
+type ImportOptions struct {
+	Format string
+	// DryRun reports what the import would do without changing anything
+	DryRun bool
+	// SkipInvalid imports the valid rows when some are invalid instead of rejecting the whole file
+	SkipInvalid bool
+}
+
+// ImportRow identifies a question bank row in an import report; rows are numbered from 1
+type ImportRow struct {
+	Row          int    `json:"row"`
+	Key          string `json:"key"`
+	Section      string `json:"section"`
+	QuestionText string `json:"questionText"`
+}
+
+// ImportError is a row that could not be imported
+type ImportError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+// ImportReport lists what an import created, updated, skipped as duplicate and rejected
+type ImportReport struct {
+	DryRun     bool          `json:"dryRun"`
+	Applied    bool          `json:"applied"`
+	Created    []ImportRow   `json:"created"`
+	Updated    []ImportRow   `json:"updated"`
+	Duplicates []ImportRow   `json:"duplicates"`
+	Errors     []ImportError `json:"errors"`
+}
+
+// IsValidQuestionBankFormat reports whether format is a supported question bank file format
+func IsValidQuestionBankFormat(format string) bool {
+	return format == QuestionBankCSV || format == QuestionBankJSON || format == QuestionBankYAML
+}
+
+// QuestionKey derives a stable key from a question's section and text, e.g. "food.pizza-diavola"
+func QuestionKey(section, questionText string) string {
+	return slug(section) + "." + slug(questionText)
+}
+
+// slug lowercases text and joins its runs of letters and digits with hyphens
+func slug(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, "-")
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DefaultPackKey identifies the pack that questions without a pack belong to
+const DefaultPackKey = "default"
+
+// QuestionPack is a named set of questions. The pack's questions in the questions collection are
+// its draft; new sessions are served the immutable snapshot of its published version.
+type QuestionPack struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	// Key is derived from the name when the pack is created and never changes
+	Key         string `bson:"key" json:"key"`
+	Name        string `bson:"name" json:"name"`
+	Description string `bson:"description,omitempty" json:"description,omitempty"`
+	// PublishedVersion is the version served to new sessions; 0 until the pack is first published
+	PublishedVersion int `bson:"publishedVersion" json:"publishedVersion"`
+	// LatestVersion is the highest version published so far, which can be newer after a rollback
+	LatestVersion int       `bson:"latestVersion" json:"latestVersion"`
+	CreatedAt     time.Time `bson:"createdAt" json:"createdAt"`
+}
+
+// PackVersion is an immutable snapshot of a pack's questions taken when it was published
+type PackVersion struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	PackID      primitive.ObjectID `bson:"packId" json:"packId"`
+	Version     int                `bson:"version" json:"version"`
+	Note        string             `bson:"note,omitempty" json:"note,omitempty"`
+	PublishedAt time.Time          `bson:"publishedAt" json:"publishedAt"`
+	// Questions are in section order, then question order; left out when versions are listed
+	Questions     []Question `bson:"questions,omitempty" json:"questions,omitempty"`
+	QuestionCount int        `bson:"questionCount" json:"questionCount"`
+}
+
+// PackChange identifies a question that differs between a pack's draft and its published version
+type PackChange struct {
+	QuestionID   string `json:"questionId"`
+	Section      string `json:"section"`
+	QuestionText string `json:"questionText"`
+}
+
+// PackPreview shows the questions a pack would publish and how they differ from the published version
+type PackPreview struct {
+	Pack             QuestionPack `json:"pack"`
+	PublishedVersion int          `json:"publishedVersion"`
+	Questions        []Question   `json:"questions"`
+	Added            []PackChange `json:"added"`
+	Removed          []PackChange `json:"removed"`
+	Changed          []PackChange `json:"changed"`
+}
+
+// PackKey derives the key of a pack from its name, e.g. "summer-camp" for "Summer camp"
+func PackKey(name string) string {
+	return slug(name)
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// QuestionStatsFilter selects the completed sessions question statistics are computed over
+type QuestionStatsFilter struct {
+	// QuestionIDs limits the statistics to these questions; empty means every question
+	QuestionIDs []primitive.ObjectID
+	// From and To bound the sessions' creation time; From is inclusive, To exclusive, and nil is unbounded
+	From *time.Time
+	To   *time.Time
+}
+
+// QuestionPairCount counts how the two players of completed sessions answered a question together
+type QuestionPairCount struct {
+	QuestionID primitive.ObjectID
+	// Pairs is the number of sessions where both players answered the question
+	Pairs int
+	// Agreed is the number of pairs who gave the same response, shared negative ones included
+	Agreed int
+	// Matched is the number of pairs whose shared response counts as a match, as in the spec score
+	Matched int
+}
+
+// ResponseStat is how often one response was given to a question
+type ResponseStat struct {
+	Response string `json:"response"`
+	Count    int    `json:"count"`
+	// Share is the percentage of the question's answers that gave this response
+	Share int `json:"share"`
+}
+
+// QuestionStats describes how players answer a question: the distribution of their responses,
+// how often partners agree and how often the question makes a match
+type QuestionStats struct {
+	Question Question `json:"question"`
+	// Answers is the number of responses given to the question, by either player
+	Answers int `json:"answers"`
+	// Distribution lists every response of the question's scale, most given first
+	Distribution []ResponseStat `json:"distribution"`
+	// TopShare is the share of the most given response; near 100 means nearly everyone answers alike
+	TopShare int `json:"topShare"`
+	// Pairs is the number of sessions where both partners answered the question
+	Pairs int `json:"pairs"`
+	// AgreementRate is the percentage of pairs who gave the same response
+	AgreementRate int `json:"agreementRate"`
+	// Matches is the number of pairs the question counted as a match for; MatchRate is its percentage of Pairs
+	Matches   int `json:"matches"`
+	MatchRate int `json:"matchRate"`
+}
@@ -2,8 +2,43 @@ package models
 
 // CreateSessionRequest represents the request to create a new game session
 type CreateSessionRequest struct {
-	Player1Name string `json:"player1Name" binding:"required"`
-	Player2Name string `json:"player2Name" binding:"required"`
+	Player1Name     string                  `json:"player1Name" binding:"required"`
+	Player2Name     string                  `json:"player2Name" binding:"required"`
+	Mode            string                  `json:"mode"`
+	CustomQuestions []CustomQuestionRequest `json:"customQuestions"`
+	// Pack is the ID or key of the question pack to play; empty means the default pack
+	Pack string `json:"pack"`
+	// Audience and Tags restrict the questions played, see QuestionFilter
+	Audience string   `json:"audience"`
+	Tags     []string `json:"tags"`
+	// Shuffle is one of AllShuffleModes; empty means ShuffleSections
+	Shuffle string `json:"shuffle"`
+}
+
+// CustomQuestionRequest represents a question written by the session creator
+type CustomQuestionRequest struct {
+	Section      string `json:"section"`
+	QuestionText string `json:"questionText" binding:"required"`
+	ResponseType string `json:"responseType"`
+}
+
+// CreateBroadcastRequest represents the request to send one game to several partners
+type CreateBroadcastRequest struct {
+	Player1Name     string                  `json:"player1Name" binding:"required"`
+	PartnerNames    []string                `json:"partnerNames" binding:"required"`
+	CustomQuestions []CustomQuestionRequest `json:"customQuestions"`
+	// Pack is the ID or key of the question pack every session plays; empty means the default pack
+	Pack string `json:"pack"`
+	// Audience and Tags restrict the questions every session plays, see QuestionFilter
+	Audience string   `json:"audience"`
+	Tags     []string `json:"tags"`
+	// Shuffle is one of AllShuffleModes; empty means ShuffleSections. Every session gets the same order.
+	Shuffle string `json:"shuffle"`
+}
+
+// SubmitBroadcastAnswersRequest represents the request to submit player 1's answers for every partner
+type SubmitBroadcastAnswersRequest struct {
+	Answers []PlayerAnswer `json:"answers" binding:"required"`
 }
 
 // JoinSessionRequest represents the request for Player 2 to join a session
@@ -17,6 +52,31 @@ type SubmitAnswersRequest struct {
 	Answers  []PlayerAnswer `json:"answers" binding:"required"`
 }
 
+// JoinLobbyRequest represents the request to enter the matchmaking lobby
+type JoinLobbyRequest struct {
+	PlayerName string `json:"playerName" binding:"required"`
+	Group      string `json:"group"`
+	AgeBand    string `json:"ageBand"`
+	ClientID   string `json:"clientId"`
+}
+
+// CreateRoomRequest represents the request to open a hosted party room
+type CreateRoomRequest struct {
+	Name string `json:"name" binding:"required"`
+	// Pack is the ID or key of the question pack to play; empty means the default pack
+	Pack string `json:"pack"`
+}
+
+// JoinRoomRequest represents the request to join a party room
+type JoinRoomRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// SubmitRoomAnswersRequest represents the request to submit a room participant's answers
+type SubmitRoomAnswersRequest struct {
+	Answers []PlayerAnswer `json:"answers" binding:"required"`
+}
+
 // CreatePlayerRequest represents the request to create a new player
 type CreatePlayerRequest struct {
 	Name string `json:"name" binding:"required"`
@@ -29,12 +89,87 @@ type UpdatePlayerRequest struct {
 
 // CreateQuestionRequest represents the request to create a new question
 type CreateQuestionRequest struct {
-	Section      string `json:"section" binding:"required"`
-	QuestionText string `json:"questionText" binding:"required"`
+	Section      string   `json:"section"`
+	SectionID    string   `json:"sectionId"`
+	Order        int      `json:"order"`
+	QuestionText string   `json:"questionText" binding:"required"`
+	ResponseType string   `json:"responseType"`
+	Weight       *float64 `json:"weight"`
+	Key          string   `json:"key"`
+	// Translations maps a language code to the question's text in that language
+	Translations map[string]QuestionTranslation `json:"translations"`
+	// PackID is the ID or key of the pack whose draft gets the question; empty means the default pack
+	PackID string `json:"packId"`
+	// Tags are the question's topics; Rating is one of AllRatings, unset meaning RatingEveryone
+	Tags   []string `json:"tags"`
+	Rating string   `json:"rating"`
 }
 
-// UpdateQuestionRequest represents the request to update a question
+// UpdateQuestionRequest represents the request to update a question; omitted fields keep their
+// current values
 type UpdateQuestionRequest struct {
-	Section      string `json:"section" binding:"required"`
-	QuestionText string `json:"questionText" binding:"required"`
+	Section      string `json:"section"`
+	SectionID    string `json:"sectionId"`
+	Order        *int   `json:"order"`
+	QuestionText string `json:"questionText"`
+	ResponseType string `json:"responseType"`
+	// Weight sets the question's weight; 0 resets it to DefaultWeight
+	Weight *float64 `json:"weight"`
+	// Translations replaces the question's translations when given; an empty map removes them
+	Translations map[string]QuestionTranslation `json:"translations"`
+	// PackID moves the question to the draft of another pack when given
+	PackID string `json:"packId"`
+	// Tags and Rating replace the question's tags and rating when given; an empty list or rating clears them
+	Tags   *[]string `json:"tags"`
+	Rating *string   `json:"rating"`
+}
+
+// CreateSectionRequest represents the request to create a new section
+type CreateSectionRequest struct {
+	Name         string `json:"name" binding:"required"`
+	Description  string `json:"description"`
+	Icon         string `json:"icon"`
+	DisplayOrder *int   `json:"displayOrder"`
+	Active       *bool  `json:"active"`
+	// Translations maps a language code to the section's name and description in that language
+	Translations map[string]SectionTranslation `json:"translations"`
+}
+
+// UpdateSectionRequest represents the request to update a section; an omitted description, icon,
+// displayOrder or active keeps the section's current value
+type UpdateSectionRequest struct {
+	Name         string  `json:"name" binding:"required"`
+	Description  *string `json:"description"`
+	Icon         *string `json:"icon"`
+	DisplayOrder *int    `json:"displayOrder"`
+	Active       *bool   `json:"active"`
+	// Translations replaces the section's translations when given
+	Translations map[string]SectionTranslation `json:"translations"`
+}
+
+// UpdateSectionWeightRequest represents the request to set the weight of a section
+type UpdateSectionWeightRequest struct {
+	Weight float64 `json:"weight" binding:"required"`
+}
+
+// CreatePackRequest represents the request to create a question pack
+type CreatePackRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// UpdatePackRequest represents the request to update a question pack's name and description
+type UpdatePackRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// PublishPackRequest represents the request to publish a pack's draft
+type PublishPackRequest struct {
+	Note string `json:"note"`
+}
+
+// RollbackPackRequest represents the request to serve an earlier published version of a pack again
+type RollbackPackRequest struct {
+	Version int `json:"version" binding:"required"`
 }
@@ -11,3 +11,13 @@ const (
 func AllResponseTypes() []string {
 	return []string{Yay, Nay, DontCare}
 }
+
+// IsValidResponseType reports whether response is one of the possible response types
+func IsValidResponseType(response string) bool {
+	for _, r := range AllResponseTypes() {
+		if r == response {
+			return true
+		}
+	}
+	return false
+}
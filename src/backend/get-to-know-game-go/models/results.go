@@ -0,0 +1,38 @@
+package models
+
+// SharedAnswer is a question both players answered with the same counted response
+type SharedAnswer struct {
+	QuestionID   string `json:"questionId"`
+	QuestionText string `json:"questionText"`
+	Response     string `json:"response"`
+}
+
+// SectionSharedAnswers groups shared answers by question section
+type SectionSharedAnswers struct {
+	Section string         `json:"section"`
+	Answers []SharedAnswer `json:"answers"`
+}
+
+// SessionResults is the result view of a completed game session
+type SessionResults struct {
+	SessionID          string                 `json:"sessionId"`
+	Mode               string                 `json:"mode"`
+	Player1Name        string                 `json:"player1Name"`
+	Player2Name        string                 `json:"player2Name"`
+	CompatibilityScore int                    `json:"compatibilityScore"`
+	Emoji              string                 `json:"emoji"`
+	ScoringStrategy    string                 `json:"scoringStrategy"`
+	ScoringVersion     int                    `json:"scoringVersion"`
+	AlternateScore     *AlternateScore        `json:"alternateScore,omitempty"`
+	SectionScores      []SectionScore         `json:"sectionScores"`
+	Dealbreakers       []DealbreakerResult    `json:"dealbreakers"`
+	ScoreCapped        bool                   `json:"scoreCapped"`
+	SharedAnswers      []SectionSharedAnswers `json:"sharedAnswers"`
+	Player1Accuracy    *PredictionAccuracy    `json:"player1Accuracy,omitempty"`
+	Player2Accuracy    *PredictionAccuracy    `json:"player2Accuracy,omitempty"`
+	// Percentile is the percentage of pairs that scored lower; QuestionSetPercentile only counts pairs with the same questions
+	Percentile            *int `json:"percentile,omitempty"`
+	QuestionSetPercentile *int `json:"questionSetPercentile,omitempty"`
+	// RareSharedAnswers are the shared answers the fewest other players give
+	RareSharedAnswers []RareSharedAnswer `json:"rareSharedAnswers"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Room represents a hosted party room where every participant answers once
+// and is compared against every other participant
+type Room struct {
+	ID            primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	Name          string               `bson:"name" json:"name"`
+	HostTokenHash string               `bson:"hostTokenHash" json:"-"`
+	JoinCode      string               `bson:"joinCode,omitempty" json:"joinCode,omitempty"`
+	QuestionIDs   []primitive.ObjectID `bson:"questionIds" json:"questionIds"`
+	Participants  []RoomParticipant    `bson:"participants" json:"-"`
+	CreatedAt     time.Time            `bson:"createdAt" json:"createdAt"`
+	ExpiresAt     time.Time            `bson:"expiresAt" json:"expiresAt"`
+	// PackID and PackVersion pin the published question pack version the room plays
+	PackID      primitive.ObjectID `bson:"packId,omitempty" json:"packId,omitempty"`
+	PackVersion int                `bson:"packVersion,omitempty" json:"packVersion,omitempty"`
+}
+
+// RoomParticipant represents a player in a room
+type RoomParticipant struct {
+	ID         primitive.ObjectID `bson:"id" json:"id"`
+	Name       string             `bson:"name" json:"name"`
+	TokenHash  string             `bson:"tokenHash" json:"-"`
+	Answers    []PlayerAnswer     `bson:"answers,omitempty" json:"-"`
+	AnsweredAt *time.Time         `bson:"answeredAt,omitempty" json:"answeredAt,omitempty"`
+}
+
+// HasAnswered reports whether the participant has submitted their answers
+func (p RoomParticipant) HasAnswered() bool {
+	return p.AnsweredAt != nil
+}
+
+// RoomPair is the compatibility of two room participants
+type RoomPair struct {
+	Participant1ID     string `json:"participant1Id"`
+	Participant1Name   string `json:"participant1Name"`
+	Participant2ID     string `json:"participant2Id"`
+	Participant2Name   string `json:"participant2Name"`
+	CompatibilityScore int    `json:"compatibilityScore"`
+	Emoji              string `json:"emoji"`
+}
+
+// RoomMatch is another participant's compatibility with a given participant
+type RoomMatch struct {
+	ParticipantID      string `json:"participantId"`
+	Name               string `json:"name"`
+	CompatibilityScore int    `json:"compatibilityScore"`
+	Emoji              string `json:"emoji"`
+}
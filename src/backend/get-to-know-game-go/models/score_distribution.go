@@ -0,0 +1,46 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// OverallDistributionKey identifies the score distribution over every completed session
+const OverallDistributionKey = "all"
+
+// ScoreDistribution counts how many completed sessions reached each score, overall or for one question set
+type ScoreDistribution struct {
+	Key string `bson:"_id" json:"key"`
+	// Counts maps each score from "0" to "100" to the number of sessions with that score
+	Counts    map[string]int `bson:"counts" json:"-"`
+	Total     int            `bson:"total" json:"total"`
+	UpdatedAt time.Time      `bson:"updatedAt" json:"updatedAt"`
+}
+
+// ScoreCount is the number of sessions of a question set that reached one score
+type ScoreCount struct {
+	QuestionSetKey string
+	Score          int
+	Count          int
+}
+
+// Histogram returns the number of sessions for every score from 0 to 100
+func (d ScoreDistribution) Histogram() []int {
+	histogram := make([]int, 101)
+	for score := range histogram {
+		histogram[score] = d.Counts[strconv.Itoa(score)]
+	}
+	return histogram
+}
+
+// PercentileOf returns the percentage of sessions that scored lower than score
+func (d ScoreDistribution) PercentileOf(score int) int {
+	if d.Total == 0 {
+		return 0
+	}
+	lower := 0
+	for s := 0; s < score && s <= 100; s++ {
+		lower += d.Counts[strconv.Itoa(s)]
+	}
+	return lower * 100 / d.Total
+}
@@ -0,0 +1,36 @@
+package models
+
+// Reasons a question did or didn't count towards the score
+const (
+	ReasonBothYay      = "both-yay"
+	ReasonBothDontCare = "both-dont-care"
+	ReasonBothYes      = "both-yes"
+	ReasonSharedNay    = "shared-nay"
+	ReasonLikert       = "likert-distance"
+	ReasonMismatch     = "mismatch"
+)
+
+// ScoreExplanationItem explains how one question contributed to the score
+type ScoreExplanationItem struct {
+	QuestionID   string  `json:"questionId"`
+	Section      string  `json:"section"`
+	QuestionText string  `json:"questionText"`
+	Counted      bool    `json:"counted"`
+	Reason       string  `json:"reason"`
+	Weight       float64 `json:"weight"`
+	// Points is the question's contribution to the score in percentage points
+	Points      float64 `json:"points"`
+	Dealbreaker bool    `json:"dealbreaker,omitempty"`
+}
+
+// ScoreExplanation itemizes a session's score question by question
+type ScoreExplanation struct {
+	SessionID          string `json:"sessionId"`
+	ScoringStrategy    string `json:"scoringStrategy"`
+	ScoringVersion     int    `json:"scoringVersion"`
+	CompatibilityScore int    `json:"compatibilityScore"`
+	// TotalPoints is the sum of all contributions, before rounding and any dealbreaker cap
+	TotalPoints float64                `json:"totalPoints"`
+	ScoreCapped bool                   `json:"scoreCapped"`
+	Items       []ScoreExplanationItem `json:"items"`
+}
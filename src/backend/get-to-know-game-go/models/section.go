@@ -0,0 +1,33 @@
+package models
+
+import (
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Section groups questions under a heading with its own display order and metadata
+type Section struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	// Key is the normalized name used to tell sections apart, so "Food" and "food " are one section
+	Key          string `bson:"key" json:"-"`
+	Name         string `bson:"name" json:"name"`
+	Description  string `bson:"description,omitempty" json:"description,omitempty"`
+	Icon         string `bson:"icon,omitempty" json:"icon,omitempty"`
+	DisplayOrder int    `bson:"displayOrder" json:"displayOrder"`
+	Active       bool   `bson:"active" json:"active"`
+	// Translations maps a language code to the section's name and description in that language
+	Translations map[string]SectionTranslation `bson:"translations,omitempty" json:"translations,omitempty"`
+	// Weight scales the scoring weight of every question in the section; unset means DefaultWeight
+	Weight *float64 `bson:"weight,omitempty" json:"weight,omitempty"`
+}
+
+// NormalizeSectionName trims a section name and collapses inner whitespace
+func NormalizeSectionName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// SectionKey returns the key identifying the section called name
+func SectionKey(name string) string {
+	return strings.ToLower(NormalizeSectionName(name))
+}
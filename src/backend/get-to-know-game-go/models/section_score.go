@@ -0,0 +1,9 @@
+package models
+
+// SectionScore is the compatibility of two players within one question section
+type SectionScore struct {
+	Section   string `json:"section"`
+	Score     int    `json:"score"`
+	Matches   int    `json:"matches"`
+	Questions int    `json:"questions"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// SeedPack is a versioned set of default questions shipped as a data file
+type SeedPack struct {
+	Name string `json:"name" yaml:"name"`
+	// Version is raised whenever the pack's questions change so existing deployments pick them up
+	Version   int              `json:"version" yaml:"version"`
+	Questions []QuestionRecord `json:"questions" yaml:"questions"`
+}
+
+// AppliedSeedPack records the version of a seed pack last applied to the database
+type AppliedSeedPack struct {
+	Name      string    `bson:"_id" json:"name"`
+	Version   int       `bson:"version" json:"version"`
+	AppliedAt time.Time `bson:"appliedAt" json:"appliedAt"`
+}
+
+// SeedResult summarizes what seeding one pack did
+type SeedResult struct {
+	Pack    string `json:"pack"`
+	Version int    `json:"version"`
+	// Skipped is set when the pack's version was already applied
+	Skipped   bool `json:"skipped"`
+	Created   int  `json:"created"`
+	Updated   int  `json:"updated"`
+	Unchanged int  `json:"unchanged"`
+	// Kept counts questions left as an admin edited them
+	Kept int `json:"kept"`
+}
@@ -0,0 +1,21 @@
+package models
+
+// SessionScore is the canonical score of a completed session and the strategy that produced it
+type SessionScore struct {
+	CompatibilityScore int
+	ScoringStrategy    string
+	ScoringVersion     int
+	QuestionWeights    map[string]float64
+	SectionScores      []SectionScore
+	Dealbreakers       []TriggeredDealbreaker
+	ScoreCapped        bool
+	QuestionSetKey     string
+}
+
+// AlternateScore is a session scored with a strategy other than the one stored on it
+type AlternateScore struct {
+	Scorer             string `json:"scorer"`
+	Version            int    `json:"version"`
+	CompatibilityScore int    `json:"compatibilityScore"`
+	Emoji              string `json:"emoji"`
+}
@@ -0,0 +1,26 @@
+package models
+
+// Shuffle mode constants for the order a session's questions are served in
+const (
+	// ShuffleNone serves questions in section order, then question order
+	ShuffleNone = "none"
+	// ShuffleSections keeps the section order and shuffles the questions within each section
+	ShuffleSections = "sections"
+	// ShuffleAll shuffles the whole question set, mixing sections
+	ShuffleAll = "all"
+)
+
+// AllShuffleModes returns all supported shuffle modes
+func AllShuffleModes() []string {
+	return []string{ShuffleNone, ShuffleSections, ShuffleAll}
+}
+
+// IsValidShuffleMode reports whether mode is a supported shuffle mode
+func IsValidShuffleMode(mode string) bool {
+	for _, m := range AllShuffleModes() {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package models
+
+// QuestionTranslation is a question's text in another language
+type QuestionTranslation struct {
+	QuestionText string `bson:"questionText" json:"questionText" yaml:"questionText"`
+}
+
+// SectionTranslation is a section's name and description in another language
+type SectionTranslation struct {
+	Name        string `bson:"name" json:"name"`
+	Description string `bson:"description,omitempty" json:"description,omitempty"`
+}
+
+// Localized returns the question with its text in language, when translated
+func (q Question) Localized(language string) Question {
+	if translation, exists := q.Translations[language]; exists && translation.QuestionText != "" {
+		q.QuestionText = translation.QuestionText
+	}
+	return q
+}
+
+// Localized returns the section with its name and description in language, when translated
+func (s Section) Localized(language string) Section {
+	translation, exists := s.Translations[language]
+	if !exists {
+		return s
+	}
+	if translation.Name != "" {
+		s.Name = translation.Name
+	}
+	if translation.Description != "" {
+		s.Description = translation.Description
+	}
+	return s
+}
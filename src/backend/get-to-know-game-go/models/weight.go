@@ -0,0 +1,24 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+const (
+	// DefaultWeight is the weight of questions and sections that don't set one
+	DefaultWeight = 1.0
+	// MaxWeight is the largest weight a question or section can carry
+	MaxWeight = 10.0
+)
+
+// SectionWeight is the scoring weight applied to every question of a section. Weights are stored
+// on the section itself; the section_weights collection only holds entries from before that,
+// keyed by section name, until they are migrated.
+type SectionWeight struct {
+	ID      primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Section string             `bson:"section" json:"section"`
+	Weight  float64            `bson:"weight" json:"weight"`
+}
+
+// IsValidWeight reports whether weight is within the allowed range
+func IsValidWeight(weight float64) bool {
+	return weight > 0 && weight <= MaxWeight
+}
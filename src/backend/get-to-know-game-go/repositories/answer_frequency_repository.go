@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"get-to-know-game-go/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AnswerFrequencyRepositoryImpl implements AnswerFrequencyRepository
+type AnswerFrequencyRepositoryImpl struct {
+	*BaseRepository[models.AnswerFrequency]
+}
+
+// NewAnswerFrequencyRepository creates a new answer frequency repository
+func NewAnswerFrequencyRepository(collection *mongo.Collection) AnswerFrequencyRepository {
+	return &AnswerFrequencyRepositoryImpl{
+		BaseRepository: NewBaseRepository[models.AnswerFrequency](collection),
+	}
+}
+
+// GetByQuestionIDs retrieves the answer frequencies of the given questions
+func (r *AnswerFrequencyRepositoryImpl) GetByQuestionIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.AnswerFrequency, error) {
+	cursor, err := r.BaseRepository.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var frequencies []models.AnswerFrequency
+	if err := cursor.All(ctx, &frequencies); err != nil {
+		return nil, err
+	}
+
+	return frequencies, nil
+}
+
+// Increment counts one more occurrence of each answer's response, creating entries as needed
+func (r *AnswerFrequencyRepositoryImpl) Increment(ctx context.Context, answers []models.PlayerAnswer) error {
+	if len(answers) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	writes := make([]mongo.WriteModel, 0, len(answers))
+	for _, answer := range answers {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": answer.QuestionID}).
+			SetUpdate(bson.M{
+				"$inc": bson.M{"counts." + answer.Response: 1, "total": 1},
+				"$set": bson.M{"updatedAt": now},
+			}).
+			SetUpsert(true))
+	}
+
+	_, err := r.BaseRepository.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// InsertMissing stores the answer frequencies of the questions that have none yet. Stored
+// frequencies are left as they are, so increments made while they were being built are kept.
+func (r *AnswerFrequencyRepositoryImpl) InsertMissing(ctx context.Context, frequencies []models.AnswerFrequency) error {
+	if len(frequencies) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(frequencies))
+	for _, frequency := range frequencies {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": frequency.QuestionID}).
+			SetUpdate(bson.M{"$setOnInsert": bson.M{
+				"counts":    frequency.Counts,
+				"total":     frequency.Total,
+				"updatedAt": frequency.UpdatedAt,
+			}}).
+			SetUpsert(true))
+	}
+
+	_, err := r.BaseRepository.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// Count returns the number of questions with recorded answer frequencies
+func (r *AnswerFrequencyRepositoryImpl) Count(ctx context.Context) (int64, error) {
+	return r.BaseRepository.collection.CountDocuments(ctx, bson.M{})
+}
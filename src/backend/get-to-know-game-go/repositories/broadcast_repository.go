@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"get-to-know-game-go/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// BroadcastRepositoryImpl implements BroadcastRepository
+type BroadcastRepositoryImpl struct {
+	*BaseRepository[models.Broadcast]
+}
+
+// NewBroadcastRepository creates a new broadcast repository
+func NewBroadcastRepository(collection *mongo.Collection) BroadcastRepository {
+	return &BroadcastRepositoryImpl{
+		BaseRepository: NewBaseRepository[models.Broadcast](collection),
+	}
+}
+
+// UpdateAnswers updates player 1's answers on a broadcast
+func (r *BroadcastRepositoryImpl) UpdateAnswers(ctx context.Context, id string, answers []models.PlayerAnswer) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid broadcast ID format: %v", err)
+	}
+
+	update := bson.M{"$set": bson.M{"player1Answers": answers}}
+	result, err := r.BaseRepository.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("broadcast not found")
+	}
+
+	return nil
+}
@@ -23,16 +23,17 @@ func NewGameSessionRepository(collection *mongo.Collection) GameSessionRepositor
 	}
 }
 
-// UpdateAnswers updates player answers in a game session
-func (r *GameSessionRepositoryImpl) UpdateAnswers(ctx context.Context, id string, playerID string, answers []models.PlayerAnswer) error {
+// UpdateAnswers updates player answers in a game session that hasn't been scored yet.
+// It returns false if the session has been scored, when answers can no longer change.
+func (r *GameSessionRepositoryImpl) UpdateAnswers(ctx context.Context, id string, playerID string, answers []models.PlayerAnswer) (bool, error) {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return fmt.Errorf("invalid session ID format: %v", err)
+		return false, fmt.Errorf("invalid session ID format: %v", err)
 	}
 
 	playerObjectID, err := primitive.ObjectIDFromHex(playerID)
 	if err != nil {
-		return fmt.Errorf("invalid player ID format: %v", err)
+		return false, fmt.Errorf("invalid player ID format: %v", err)
 	}
 
 	// Determine which player field to update
@@ -40,7 +41,7 @@ func (r *GameSessionRepositoryImpl) UpdateAnswers(ctx context.Context, id string
 	var session models.GameSession
 	err = r.BaseRepository.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&session)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	if session.Player1ID == playerObjectID {
@@ -48,50 +49,92 @@ func (r *GameSessionRepositoryImpl) UpdateAnswers(ctx context.Context, id string
 	} else if session.Player2ID != nil && *session.Player2ID == playerObjectID {
 		updateField = "player2Answers"
 	} else {
-		return fmt.Errorf("player does not belong to this session")
+		return false, fmt.Errorf("player does not belong to this session")
 	}
 
+	// The filter rechecks the score so a submit racing the final scoring can't change answers after it
+	filter := bson.M{"_id": objectID, "compatibilityScore": bson.M{"$exists": false}}
 	update := bson.M{"$set": bson.M{updateField: answers}}
-	result, err := r.BaseRepository.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	result, err := r.BaseRepository.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	if result.MatchedCount == 0 {
-		return fmt.Errorf("session not found")
+	return result.MatchedCount == 1, nil
+}
+
+// UpdateCompatibilityScore stores the compatibility score and the strategy it was scored with.
+// A session is scored once: it returns false if the session already has a score.
+func (r *GameSessionRepositoryImpl) UpdateCompatibilityScore(ctx context.Context, id string, score models.SessionScore) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid session ID format: %v", err)
 	}
 
-	return nil
+	update := bson.M{"$set": bson.M{
+		"compatibilityScore": score.CompatibilityScore,
+		"scoringStrategy":    score.ScoringStrategy,
+		"scoringVersion":     score.ScoringVersion,
+		"questionWeights":    score.QuestionWeights,
+		"sectionScores":      score.SectionScores,
+		"dealbreakers":       score.Dealbreakers,
+		"scoreCapped":        score.ScoreCapped,
+		"questionSetKey":     score.QuestionSetKey,
+	}}
+	filter := bson.M{"_id": objectID, "compatibilityScore": bson.M{"$exists": false}}
+	result, err := r.BaseRepository.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount == 1, nil
 }
 
-// UpdateCompatibilityScore updates the compatibility score for a game session
-func (r *GameSessionRepositoryImpl) UpdateCompatibilityScore(ctx context.Context, id string, score int) error {
+// UpdatePlayer2 updates the session with player 2 information.
+// It fails if another player has already joined the session.
+func (r *GameSessionRepositoryImpl) UpdatePlayer2(ctx context.Context, id string, player2ID primitive.ObjectID, tokenHash string) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return fmt.Errorf("invalid session ID format: %v", err)
 	}
 
-	update := bson.M{"$set": bson.M{"compatibilityScore": score}}
-	result, err := r.BaseRepository.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	filter := bson.M{"_id": objectID, "player2Id": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"player2Id": player2ID, "player2TokenHash": tokenHash}}
+	result, err := r.BaseRepository.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
 	}
 
 	if result.MatchedCount == 0 {
-		return fmt.Errorf("session not found")
+		return fmt.Errorf("session not found or already full")
 	}
 
 	return nil
 }
 
-// UpdatePlayer2 updates the session with player 2 information
-func (r *GameSessionRepositoryImpl) UpdatePlayer2(ctx context.Context, id string, player2ID primitive.ObjectID) error {
+// UpdateTokenHash replaces the stored access token hash of a session player
+func (r *GameSessionRepositoryImpl) UpdateTokenHash(ctx context.Context, id string, playerID primitive.ObjectID, tokenHash string) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return fmt.Errorf("invalid session ID format: %v", err)
 	}
 
-	update := bson.M{"$set": bson.M{"player2Id": player2ID}}
+	var session models.GameSession
+	err = r.BaseRepository.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&session)
+	if err != nil {
+		return err
+	}
+
+	var updateField string
+	if session.Player1ID == playerID {
+		updateField = "player1TokenHash"
+	} else if session.Player2ID != nil && *session.Player2ID == playerID {
+		updateField = "player2TokenHash"
+	} else {
+		return fmt.Errorf("player does not belong to this session")
+	}
+
+	update := bson.M{"$set": bson.M{updateField: tokenHash}}
 	result, err := r.BaseRepository.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
 	if err != nil {
 		return err
@@ -102,4 +145,233 @@ func (r *GameSessionRepositoryImpl) UpdatePlayer2(ctx context.Context, id string
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// ScoreCounts counts the scored sessions per question set and score
+func (r *GameSessionRepositoryImpl) ScoreCounts(ctx context.Context) ([]models.ScoreCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"compatibilityScore": bson.M{"$exists": true}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"questionSetKey": "$questionSetKey", "score": "$compatibilityScore"},
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.BaseRepository.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		ID struct {
+			QuestionSetKey string `bson:"questionSetKey"`
+			Score          int    `bson:"score"`
+		} `bson:"_id"`
+		Count int `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+
+	counts := make([]models.ScoreCount, 0, len(groups))
+	for _, group := range groups {
+		counts = append(counts, models.ScoreCount{
+			QuestionSetKey: group.ID.QuestionSetKey,
+			Score:          group.ID.Score,
+			Count:          group.Count,
+		})
+	}
+	return counts, nil
+}
+
+// AnswerCounts counts the responses given to each question across scored sessions, both players
+// included. Answers to a session's custom questions are left out.
+func (r *GameSessionRepositoryImpl) AnswerCounts(ctx context.Context) ([]models.AnswerCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"compatibilityScore": bson.M{"$exists": true}}}},
+		{{Key: "$project", Value: bson.M{
+			"answers": bson.M{"$filter": bson.M{
+				"input": bson.M{"$concatArrays": bson.A{"$player1Answers", bson.M{"$ifNull": bson.A{"$player2Answers", bson.A{}}}}},
+				"as":    "answer",
+				"cond": bson.M{"$not": bson.A{bson.M{"$in": bson.A{
+					"$$answer.questionId",
+					bson.M{"$ifNull": bson.A{"$customQuestions._id", bson.A{}}},
+				}}}},
+			}},
+		}}},
+		{{Key: "$unwind", Value: "$answers"}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"questionId": "$answers.questionId", "response": "$answers.response"},
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.BaseRepository.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		ID struct {
+			QuestionID primitive.ObjectID `bson:"questionId"`
+			Response   string             `bson:"response"`
+		} `bson:"_id"`
+		Count int `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+
+	counts := make([]models.AnswerCount, 0, len(groups))
+	for _, group := range groups {
+		counts = append(counts, models.AnswerCount{
+			QuestionID: group.ID.QuestionID,
+			Response:   group.ID.Response,
+			Count:      group.Count,
+		})
+	}
+	return counts, nil
+}
+
+// QuestionResponseCounts counts the responses given to questions across scored sessions created
+// within the filter's date range, both players included
+func (r *GameSessionRepositoryImpl) QuestionResponseCounts(ctx context.Context, filter models.QuestionStatsFilter) ([]models.AnswerCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: questionStatsMatch(filter)}},
+		{{Key: "$project", Value: bson.M{
+			"answers": bson.M{"$concatArrays": bson.A{"$player1Answers", bson.M{"$ifNull": bson.A{"$player2Answers", bson.A{}}}}},
+		}}},
+		{{Key: "$unwind", Value: "$answers"}},
+	}
+	if len(filter.QuestionIDs) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"answers.questionId": bson.M{"$in": filter.QuestionIDs}}}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$group", Value: bson.M{
+		"_id":   bson.M{"questionId": "$answers.questionId", "response": "$answers.response"},
+		"count": bson.M{"$sum": 1},
+	}}})
+
+	cursor, err := r.BaseRepository.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		ID struct {
+			QuestionID primitive.ObjectID `bson:"questionId"`
+			Response   string             `bson:"response"`
+		} `bson:"_id"`
+		Count int `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+
+	counts := make([]models.AnswerCount, 0, len(groups))
+	for _, group := range groups {
+		counts = append(counts, models.AnswerCount{
+			QuestionID: group.ID.QuestionID,
+			Response:   group.ID.Response,
+			Count:      group.Count,
+		})
+	}
+	return counts, nil
+}
+
+// QuestionPairCounts pairs each player 1 answer with player 2's answer to the same question across
+// scored sessions created within the filter's date range, and counts per question how many pairs
+// agreed and how many matched: agreed on a response that is not one of negativeResponses
+func (r *GameSessionRepositoryImpl) QuestionPairCounts(ctx context.Context, filter models.QuestionStatsFilter, negativeResponses []string) ([]models.QuestionPairCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: questionStatsMatch(filter)}},
+		{{Key: "$unwind", Value: "$player1Answers"}},
+		{{Key: "$project", Value: bson.M{
+			"questionId": "$player1Answers.questionId",
+			"response1":  "$player1Answers.response",
+			"response2": bson.M{"$arrayElemAt": bson.A{
+				bson.M{"$map": bson.M{
+					"input": bson.M{"$filter": bson.M{
+						"input": "$player2Answers",
+						"as":    "answer",
+						"cond":  bson.M{"$eq": bson.A{"$$answer.questionId", "$player1Answers.questionId"}},
+					}},
+					"as": "answer",
+					"in": "$$answer.response",
+				}},
+				0,
+			}},
+		}}},
+		{{Key: "$match", Value: questionPairMatch(filter)}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$questionId",
+			"pairs": bson.M{"$sum": 1},
+			"agreed": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$response1", "$response2"}}, 1, 0,
+			}}},
+			"matched": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$and": bson.A{
+					bson.M{"$eq": bson.A{"$response1", "$response2"}},
+					bson.M{"$not": bson.A{bson.M{"$in": bson.A{"$response1", negativeResponses}}}},
+				}}, 1, 0,
+			}}},
+		}}},
+	}
+
+	cursor, err := r.BaseRepository.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		QuestionID primitive.ObjectID `bson:"_id"`
+		Pairs      int                `bson:"pairs"`
+		Agreed     int                `bson:"agreed"`
+		Matched    int                `bson:"matched"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+
+	counts := make([]models.QuestionPairCount, 0, len(groups))
+	for _, group := range groups {
+		counts = append(counts, models.QuestionPairCount{
+			QuestionID: group.QuestionID,
+			Pairs:      group.Pairs,
+			Agreed:     group.Agreed,
+			Matched:    group.Matched,
+		})
+	}
+	return counts, nil
+}
+
+// questionStatsMatch selects the scored sessions created within the filter's date range
+func questionStatsMatch(filter models.QuestionStatsFilter) bson.M {
+	match := bson.M{"compatibilityScore": bson.M{"$exists": true}}
+	createdAt := bson.M{}
+	if filter.From != nil {
+		createdAt["$gte"] = *filter.From
+	}
+	if filter.To != nil {
+		createdAt["$lt"] = *filter.To
+	}
+	if len(createdAt) > 0 {
+		match["createdAt"] = createdAt
+	}
+	if len(filter.QuestionIDs) > 0 {
+		match["player1Answers.questionId"] = bson.M{"$in": filter.QuestionIDs}
+	}
+	return match
+}
+
+// questionPairMatch keeps the answer pairs where player 2 answered too, for the filter's questions
+func questionPairMatch(filter models.QuestionStatsFilter) bson.M {
+	match := bson.M{"response2": bson.M{"$ne": nil}}
+	if len(filter.QuestionIDs) > 0 {
+		match["questionId"] = bson.M{"$in": filter.QuestionIDs}
+	}
+	return match
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"get-to-know-game-go/models"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -19,6 +20,47 @@ type Repository[T any] interface {
 // QuestionRepository defines question-specific operations
 type QuestionRepository interface {
 	Repository[models.Question]
+	EnsureIndexes(ctx context.Context) error
+	GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Question, error)
+	SetSection(ctx context.Context, id primitive.ObjectID, section models.Section, order int) error
+	RenameSection(ctx context.Context, sectionID primitive.ObjectID, name string) error
+	CountBySection(ctx context.Context, sectionID primitive.ObjectID) (int64, error)
+	GetByPack(ctx context.Context, packID primitive.ObjectID, includeUnassigned bool) ([]models.Question, error)
+	UpdateFields(ctx context.Context, id primitive.ObjectID, set map[string]interface{}, unset []string) error
+}
+
+// SectionRepository defines section-specific operations
+type SectionRepository interface {
+	Repository[models.Section]
+	EnsureIndexes(ctx context.Context) error
+	GetAllOrdered(ctx context.Context) ([]models.Section, error)
+	GetByKey(ctx context.Context, key string) (models.Section, error)
+	SetWeight(ctx context.Context, id primitive.ObjectID, weight *float64) error
+}
+
+// QuestionPackRepository defines question pack-specific operations
+type QuestionPackRepository interface {
+	Repository[models.QuestionPack]
+	EnsureIndexes(ctx context.Context) error
+	GetAllOrdered(ctx context.Context) ([]models.QuestionPack, error)
+	GetByKey(ctx context.Context, key string) (models.QuestionPack, error)
+	Publish(ctx context.Context, id primitive.ObjectID, expectedLatest, version int) (bool, error)
+	SetPublishedVersion(ctx context.Context, id primitive.ObjectID, version int) error
+}
+
+// PackVersionRepository defines pack version-specific operations
+type PackVersionRepository interface {
+	Repository[models.PackVersion]
+	EnsureIndexes(ctx context.Context) error
+	GetVersion(ctx context.Context, packID primitive.ObjectID, version int) (models.PackVersion, error)
+	ListByPack(ctx context.Context, packID primitive.ObjectID) ([]models.PackVersion, error)
+}
+
+// SeedPackRepository defines seed pack-specific operations
+type SeedPackRepository interface {
+	Repository[models.AppliedSeedPack]
+	GetByName(ctx context.Context, name string) (models.AppliedSeedPack, error)
+	Record(ctx context.Context, pack models.AppliedSeedPack) error
 }
 
 // PlayerRepository defines player-specific operations
@@ -30,7 +72,70 @@ type PlayerRepository interface {
 type GameSessionRepository interface {
 	Repository[models.GameSession]
 	GetByID(ctx context.Context, id string) (models.GameSession, error)
-	UpdateAnswers(ctx context.Context, id string, playerID string, answers []models.PlayerAnswer) error
-	UpdateCompatibilityScore(ctx context.Context, id string, score int) error
-	UpdatePlayer2(ctx context.Context, id string, player2ID primitive.ObjectID) error
+	UpdateAnswers(ctx context.Context, id string, playerID string, answers []models.PlayerAnswer) (bool, error)
+	UpdateCompatibilityScore(ctx context.Context, id string, score models.SessionScore) (bool, error)
+	UpdatePlayer2(ctx context.Context, id string, player2ID primitive.ObjectID, tokenHash string) error
+	UpdateTokenHash(ctx context.Context, id string, playerID primitive.ObjectID, tokenHash string) error
+	ScoreCounts(ctx context.Context) ([]models.ScoreCount, error)
+	AnswerCounts(ctx context.Context) ([]models.AnswerCount, error)
+	QuestionResponseCounts(ctx context.Context, filter models.QuestionStatsFilter) ([]models.AnswerCount, error)
+	QuestionPairCounts(ctx context.Context, filter models.QuestionStatsFilter, negativeResponses []string) ([]models.QuestionPairCount, error)
+}
+
+// JoinCodeRepository defines join code-specific operations
+type JoinCodeRepository interface {
+	Repository[models.JoinCode]
+	EnsureIndexes(ctx context.Context) error
+	GetActiveByCode(ctx context.Context, code string) (models.JoinCode, error)
+	DeleteBySession(ctx context.Context, sessionID primitive.ObjectID) error
+	DeleteByRoom(ctx context.Context, roomID primitive.ObjectID) error
+}
+
+// LobbyStore defines the shared state of the matchmaking lobby.
+// Status changes are compare-and-set so that concurrent matchers never pair a ticket twice.
+type LobbyStore interface {
+	Create(ctx context.Context, ticket models.LobbyTicket) (models.LobbyTicket, error)
+	GetByID(ctx context.Context, id string) (models.LobbyTicket, error)
+	FindWaitingByClient(ctx context.Context, clientID string) ([]models.LobbyTicket, error)
+	Transition(ctx context.Context, id primitive.ObjectID, from, to string) (bool, error)
+	ClaimPartner(ctx context.Context, ticket models.LobbyTicket, now time.Time) (*models.LobbyTicket, error)
+	SetMatch(ctx context.Context, id primitive.ObjectID, sessionID, playerID primitive.ObjectID, playerNumber int) error
+	ResetMatch(ctx context.Context, id primitive.ObjectID) error
+}
+
+// RoomRepository defines party room-specific operations
+type RoomRepository interface {
+	Repository[models.Room]
+	AddParticipant(ctx context.Context, id string, participant models.RoomParticipant, maxParticipants int) (bool, error)
+	SetParticipantAnswers(ctx context.Context, id string, participantID primitive.ObjectID, answers []models.PlayerAnswer, answeredAt time.Time) (bool, error)
+}
+
+// SectionWeightRepository defines operations on the legacy section weights, which are only read
+// to migrate them onto their sections
+type SectionWeightRepository interface {
+	Repository[models.SectionWeight]
+}
+
+// ScoreDistributionRepository defines score distribution-specific operations
+type ScoreDistributionRepository interface {
+	Repository[models.ScoreDistribution]
+	GetByKey(ctx context.Context, key string) (models.ScoreDistribution, error)
+	Increment(ctx context.Context, key string, score int, delta int) error
+	InsertMissing(ctx context.Context, distributions []models.ScoreDistribution) error
+	Count(ctx context.Context) (int64, error)
+}
+
+// AnswerFrequencyRepository defines answer frequency-specific operations
+type AnswerFrequencyRepository interface {
+	Repository[models.AnswerFrequency]
+	GetByQuestionIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.AnswerFrequency, error)
+	Increment(ctx context.Context, answers []models.PlayerAnswer) error
+	InsertMissing(ctx context.Context, frequencies []models.AnswerFrequency) error
+	Count(ctx context.Context) (int64, error)
+}
+
+// BroadcastRepository defines broadcast-specific operations
+type BroadcastRepository interface {
+	Repository[models.Broadcast]
+	UpdateAnswers(ctx context.Context, id string, answers []models.PlayerAnswer) error
 }
@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"get-to-know-game-go/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// JoinCodeRepositoryImpl implements JoinCodeRepository
+type JoinCodeRepositoryImpl struct {
+	*BaseRepository[models.JoinCode]
+}
+
+// NewJoinCodeRepository creates a new join code repository
+func NewJoinCodeRepository(collection *mongo.Collection) JoinCodeRepository {
+	return &JoinCodeRepositoryImpl{
+		BaseRepository: NewBaseRepository[models.JoinCode](collection),
+	}
+}
+
+// EnsureIndexes creates the unique code index and the TTL index that removes expired codes
+func (r *JoinCodeRepositoryImpl) EnsureIndexes(ctx context.Context) error {
+	_, err := r.BaseRepository.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "code", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	return err
+}
+
+// GetActiveByCode retrieves a join code that has not expired yet
+func (r *JoinCodeRepositoryImpl) GetActiveByCode(ctx context.Context, code string) (models.JoinCode, error) {
+	var joinCode models.JoinCode
+	filter := bson.M{"code": code, "expiresAt": bson.M{"$gt": time.Now()}}
+	err := r.BaseRepository.collection.FindOne(ctx, filter).Decode(&joinCode)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return joinCode, fmt.Errorf("join code not found")
+		}
+		return joinCode, err
+	}
+
+	return joinCode, nil
+}
+
+// DeleteBySession removes the join codes of a session
+func (r *JoinCodeRepositoryImpl) DeleteBySession(ctx context.Context, sessionID primitive.ObjectID) error {
+	_, err := r.BaseRepository.collection.DeleteMany(ctx, bson.M{"sessionId": sessionID})
+	return err
+}
+
+// DeleteByRoom removes the join codes of a party room
+func (r *JoinCodeRepositoryImpl) DeleteByRoom(ctx context.Context, roomID primitive.ObjectID) error {
+	_, err := r.BaseRepository.collection.DeleteMany(ctx, bson.M{"roomId": roomID})
+	return err
+}
@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"get-to-know-game-go/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// lobbyTicketRetention is how long finished tickets are kept before MongoDB removes them
+const lobbyTicketRetention = 24 * time.Hour
+
+// LobbyRepositoryImpl implements LobbyStore with state shared through MongoDB
+type LobbyRepositoryImpl struct {
+	*BaseRepository[models.LobbyTicket]
+}
+
+// NewLobbyRepository creates a new lobby repository
+func NewLobbyRepository(collection *mongo.Collection) *LobbyRepositoryImpl {
+	return &LobbyRepositoryImpl{
+		BaseRepository: NewBaseRepository[models.LobbyTicket](collection),
+	}
+}
+
+// EnsureIndexes creates the matchmaking lookup index and the TTL index for old tickets
+func (r *LobbyRepositoryImpl) EnsureIndexes(ctx context.Context) error {
+	_, err := r.BaseRepository.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "group", Value: 1}, {Key: "ageBand", Value: 1}, {Key: "createdAt", Value: 1}},
+		},
+		{
+			Keys:    bson.D{{Key: "createdAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(lobbyTicketRetention.Seconds())),
+		},
+	})
+	return err
+}
+
+// FindWaitingByClient retrieves the waiting tickets queued by a client
+func (r *LobbyRepositoryImpl) FindWaitingByClient(ctx context.Context, clientID string) ([]models.LobbyTicket, error) {
+	filter := bson.M{"clientId": clientID, "status": models.LobbyStatusWaiting}
+	cursor, err := r.BaseRepository.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tickets []models.LobbyTicket
+	if err = cursor.All(ctx, &tickets); err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// Transition atomically moves a ticket from one status to another.
+// It returns false if the ticket was not in the expected status.
+func (r *LobbyRepositoryImpl) Transition(ctx context.Context, id primitive.ObjectID, from, to string) (bool, error) {
+	filter := bson.M{"_id": id, "status": from}
+	update := bson.M{"$set": bson.M{"status": to}}
+	result, err := r.BaseRepository.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+// ClaimPartner atomically claims the longest waiting compatible ticket for ticket.
+// Tickets from the same client are never claimed. It returns nil if nobody is waiting.
+func (r *LobbyRepositoryImpl) ClaimPartner(ctx context.Context, ticket models.LobbyTicket, now time.Time) (*models.LobbyTicket, error) {
+	filter := bson.M{
+		"_id":       bson.M{"$ne": ticket.ID},
+		"status":    models.LobbyStatusWaiting,
+		"group":     ticket.Group,
+		"ageBand":   ticket.AgeBand,
+		"expiresAt": bson.M{"$gt": now},
+	}
+	if ticket.ClientID != "" {
+		filter["clientId"] = bson.M{"$ne": ticket.ClientID}
+	}
+
+	update := bson.M{"$set": bson.M{"status": models.LobbyStatusPairing}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "createdAt", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var partner models.LobbyTicket
+	err := r.BaseRepository.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&partner)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &partner, nil
+}
+
+// SetMatch records the session a ticket was paired into
+func (r *LobbyRepositoryImpl) SetMatch(ctx context.Context, id primitive.ObjectID, sessionID, playerID primitive.ObjectID, playerNumber int) error {
+	update := bson.M{"$set": bson.M{
+		"status":       models.LobbyStatusMatched,
+		"sessionId":    sessionID,
+		"playerId":     playerID,
+		"playerNumber": playerNumber,
+	}}
+	result, err := r.BaseRepository.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("lobby ticket not found")
+	}
+
+	return nil
+}
+
+// ResetMatch puts a pairing or matched ticket back in the queue and forgets its session
+func (r *LobbyRepositoryImpl) ResetMatch(ctx context.Context, id primitive.ObjectID) error {
+	filter := bson.M{
+		"_id":    id,
+		"status": bson.M{"$in": []string{models.LobbyStatusPairing, models.LobbyStatusMatched}},
+	}
+	update := bson.M{
+		"$set":   bson.M{"status": models.LobbyStatusWaiting},
+		"$unset": bson.M{"sessionId": "", "playerId": "", "playerNumber": ""},
+	}
+	_, err := r.BaseRepository.collection.UpdateOne(ctx, filter, update)
+	return err
+}
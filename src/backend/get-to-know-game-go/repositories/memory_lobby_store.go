@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"get-to-know-game-go/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryLobbyStore implements LobbyStore with in-process state for single instance deployments
+type MemoryLobbyStore struct {
+	mu      sync.Mutex
+	tickets map[primitive.ObjectID]*models.LobbyTicket
+}
+
+// NewMemoryLobbyStore creates a new in-memory lobby store
+func NewMemoryLobbyStore() *MemoryLobbyStore {
+	return &MemoryLobbyStore{
+		tickets: make(map[primitive.ObjectID]*models.LobbyTicket),
+	}
+}
+
+// Create stores a new ticket and drops tickets older than the retention period
+func (s *MemoryLobbyStore) Create(ctx context.Context, ticket models.LobbyTicket) (models.LobbyTicket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := time.Now().Add(-lobbyTicketRetention)
+	for id, existing := range s.tickets {
+		if existing.CreatedAt.Before(cutoff) {
+			delete(s.tickets, id)
+		}
+	}
+
+	if ticket.ID.IsZero() {
+		ticket.ID = primitive.NewObjectID()
+	}
+	stored := ticket
+	s.tickets[ticket.ID] = &stored
+	return ticket, nil
+}
+
+// GetByID retrieves a ticket by ID
+func (s *MemoryLobbyStore) GetByID(ctx context.Context, id string) (models.LobbyTicket, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return models.LobbyTicket{}, fmt.Errorf("invalid ID format: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ticket, exists := s.tickets[objectID]
+	if !exists {
+		return models.LobbyTicket{}, fmt.Errorf("document not found")
+	}
+	return *ticket, nil
+}
+
+// FindWaitingByClient retrieves the waiting tickets queued by a client
+func (s *MemoryLobbyStore) FindWaitingByClient(ctx context.Context, clientID string) ([]models.LobbyTicket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var tickets []models.LobbyTicket
+	for _, ticket := range s.tickets {
+		if ticket.ClientID == clientID && ticket.Status == models.LobbyStatusWaiting {
+			tickets = append(tickets, *ticket)
+		}
+	}
+	return tickets, nil
+}
+
+// Transition moves a ticket from one status to another.
+// It returns false if the ticket was not in the expected status.
+func (s *MemoryLobbyStore) Transition(ctx context.Context, id primitive.ObjectID, from, to string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ticket, exists := s.tickets[id]
+	if !exists || ticket.Status != from {
+		return false, nil
+	}
+	ticket.Status = to
+	return true, nil
+}
+
+// ClaimPartner claims the longest waiting compatible ticket for ticket.
+// Tickets from the same client are never claimed. It returns nil if nobody is waiting.
+func (s *MemoryLobbyStore) ClaimPartner(ctx context.Context, ticket models.LobbyTicket, now time.Time) (*models.LobbyTicket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var partner *models.LobbyTicket
+	for _, candidate := range s.tickets {
+		if candidate.ID == ticket.ID ||
+			candidate.Status != models.LobbyStatusWaiting ||
+			candidate.Group != ticket.Group ||
+			candidate.AgeBand != ticket.AgeBand ||
+			!candidate.ExpiresAt.After(now) ||
+			(ticket.ClientID != "" && candidate.ClientID == ticket.ClientID) {
+			continue
+		}
+		if partner == nil || candidate.CreatedAt.Before(partner.CreatedAt) {
+			partner = candidate
+		}
+	}
+
+	if partner == nil {
+		return nil, nil
+	}
+
+	partner.Status = models.LobbyStatusPairing
+	claimed := *partner
+	return &claimed, nil
+}
+
+// SetMatch records the session a ticket was paired into
+func (s *MemoryLobbyStore) SetMatch(ctx context.Context, id primitive.ObjectID, sessionID, playerID primitive.ObjectID, playerNumber int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ticket, exists := s.tickets[id]
+	if !exists {
+		return fmt.Errorf("lobby ticket not found")
+	}
+	ticket.Status = models.LobbyStatusMatched
+	ticket.SessionID = &sessionID
+	ticket.PlayerID = &playerID
+	ticket.PlayerNumber = playerNumber
+	return nil
+}
+
+// ResetMatch puts a pairing or matched ticket back in the queue and forgets its session
+func (s *MemoryLobbyStore) ResetMatch(ctx context.Context, id primitive.ObjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ticket, exists := s.tickets[id]
+	if !exists || (ticket.Status != models.LobbyStatusPairing && ticket.Status != models.LobbyStatusMatched) {
+		return nil
+	}
+	ticket.Status = models.LobbyStatusWaiting
+	ticket.SessionID = nil
+	ticket.PlayerID = nil
+	ticket.PlayerNumber = 0
+	return nil
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"get-to-know-game-go/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PackVersionRepositoryImpl implements PackVersionRepository
+type PackVersionRepositoryImpl struct {
+	*BaseRepository[models.PackVersion]
+}
+
+// NewPackVersionRepository creates a new pack version repository
+func NewPackVersionRepository(collection *mongo.Collection) PackVersionRepository {
+	return &PackVersionRepositoryImpl{
+		BaseRepository: NewBaseRepository[models.PackVersion](collection),
+	}
+}
+
+// EnsureIndexes creates the unique index on pack and version
+func (r *PackVersionRepositoryImpl) EnsureIndexes(ctx context.Context) error {
+	_, err := r.BaseRepository.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "packId", Value: 1}, {Key: "version", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// GetVersion retrieves one version of a pack with its questions
+func (r *PackVersionRepositoryImpl) GetVersion(ctx context.Context, packID primitive.ObjectID, version int) (models.PackVersion, error) {
+	var packVersion models.PackVersion
+	err := r.BaseRepository.collection.FindOne(ctx, bson.M{"packId": packID, "version": version}).Decode(&packVersion)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return packVersion, fmt.Errorf("pack version not found")
+		}
+		return packVersion, err
+	}
+
+	return packVersion, nil
+}
+
+// ListByPack retrieves every version of a pack, newest first, without their questions
+func (r *PackVersionRepositoryImpl) ListByPack(ctx context.Context, packID primitive.ObjectID) ([]models.PackVersion, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "version", Value: -1}}).
+		SetProjection(bson.M{"questions": 0})
+	cursor, err := r.BaseRepository.collection.Find(ctx, bson.M{"packId": packID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	versions := []models.PackVersion{}
+	if err := cursor.All(ctx, &versions); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"get-to-know-game-go/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// QuestionPackRepositoryImpl implements QuestionPackRepository
+type QuestionPackRepositoryImpl struct {
+	*BaseRepository[models.QuestionPack]
+}
+
+// NewQuestionPackRepository creates a new question pack repository
+func NewQuestionPackRepository(collection *mongo.Collection) QuestionPackRepository {
+	return &QuestionPackRepositoryImpl{
+		BaseRepository: NewBaseRepository[models.QuestionPack](collection),
+	}
+}
+
+// EnsureIndexes creates the unique pack key index
+func (r *QuestionPackRepositoryImpl) EnsureIndexes(ctx context.Context) error {
+	_, err := r.BaseRepository.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "key", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// GetAllOrdered retrieves every pack by name
+func (r *QuestionPackRepositoryImpl) GetAllOrdered(ctx context.Context) ([]models.QuestionPack, error) {
+	cursor, err := r.BaseRepository.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	packs := []models.QuestionPack{}
+	if err := cursor.All(ctx, &packs); err != nil {
+		return nil, err
+	}
+
+	return packs, nil
+}
+
+// GetByKey retrieves a pack by its key
+func (r *QuestionPackRepositoryImpl) GetByKey(ctx context.Context, key string) (models.QuestionPack, error) {
+	var pack models.QuestionPack
+	err := r.BaseRepository.collection.FindOne(ctx, bson.M{"key": key}).Decode(&pack)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return pack, fmt.Errorf("question pack not found")
+		}
+		return pack, err
+	}
+
+	return pack, nil
+}
+
+// Publish makes version the published and latest version of the pack, provided no other
+// version was published since expectedLatest. It reports whether the pack was updated.
+func (r *QuestionPackRepositoryImpl) Publish(ctx context.Context, id primitive.ObjectID, expectedLatest, version int) (bool, error) {
+	result, err := r.BaseRepository.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "latestVersion": expectedLatest},
+		bson.M{"$set": bson.M{"publishedVersion": version, "latestVersion": version}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount == 1, nil
+}
+
+// SetPublishedVersion serves an already published version of the pack to new sessions
+func (r *QuestionPackRepositoryImpl) SetPublishedVersion(ctx context.Context, id primitive.ObjectID, version int) error {
+	result, err := r.BaseRepository.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"publishedVersion": version}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("question pack not found")
+	}
+	return nil
+}
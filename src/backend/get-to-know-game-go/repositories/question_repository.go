@@ -1,9 +1,14 @@
 package repositories
 
 import (
+	"context"
+
 	"get-to-know-game-go/models"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // QuestionRepositoryImpl implements QuestionRepository
@@ -17,3 +22,88 @@ func NewQuestionRepository(collection *mongo.Collection) QuestionRepository {
 		BaseRepository: NewBaseRepository[models.Question](collection),
 	}
 }
+
+// GetByIDs retrieves the questions with the given IDs
+func (r *QuestionRepositoryImpl) GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Question, error) {
+	cursor, err := r.BaseRepository.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var questions []models.Question
+	if err = cursor.All(ctx, &questions); err != nil {
+		return nil, err
+	}
+
+	return questions, nil
+}
+
+// SetSection assigns a question to a section at the given position
+func (r *QuestionRepositoryImpl) SetSection(ctx context.Context, id primitive.ObjectID, section models.Section, order int) error {
+	update := bson.M{"$set": bson.M{"sectionId": section.ID, "section": section.Name, "order": order}}
+	_, err := r.BaseRepository.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
+// UpdateFields sets and removes the given fields of a question, leaving the others as they are
+func (r *QuestionRepositoryImpl) UpdateFields(ctx context.Context, id primitive.ObjectID, set map[string]interface{}, unset []string) error {
+	update := bson.M{}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	if len(unset) > 0 {
+		fields := bson.M{}
+		for _, field := range unset {
+			fields[field] = ""
+		}
+		update["$unset"] = fields
+	}
+	if len(update) == 0 {
+		return nil
+	}
+
+	_, err := r.BaseRepository.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
+// RenameSection updates the section name stored on every question of a section
+func (r *QuestionRepositoryImpl) RenameSection(ctx context.Context, sectionID primitive.ObjectID, name string) error {
+	_, err := r.BaseRepository.collection.UpdateMany(ctx, bson.M{"sectionId": sectionID}, bson.M{"$set": bson.M{"section": name}})
+	return err
+}
+
+// CountBySection counts the questions of a section
+func (r *QuestionRepositoryImpl) CountBySection(ctx context.Context, sectionID primitive.ObjectID) (int64, error) {
+	return r.BaseRepository.collection.CountDocuments(ctx, bson.M{"sectionId": sectionID})
+}
+
+// EnsureIndexes creates the unique index on question keys; questions without a key are left out
+func (r *QuestionRepositoryImpl) EnsureIndexes(ctx context.Context) error {
+	_, err := r.BaseRepository.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "key", Value: 1}},
+		Options: options.Index().SetUnique(true).SetSparse(true),
+	})
+	return err
+}
+
+// GetByPack retrieves the draft questions of a pack; includeUnassigned adds questions without a pack
+func (r *QuestionRepositoryImpl) GetByPack(ctx context.Context, packID primitive.ObjectID, includeUnassigned bool) ([]models.Question, error) {
+	filter := bson.M{"packId": packID}
+	if includeUnassigned {
+		filter = bson.M{"$or": bson.A{filter, bson.M{"packId": bson.M{"$exists": false}}}}
+	}
+
+	cursor, err := r.BaseRepository.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	questions := []models.Question{}
+	if err = cursor.All(ctx, &questions); err != nil {
+		return nil, err
+	}
+
+	return questions, nil
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"get-to-know-game-go/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RoomRepositoryImpl implements RoomRepository
+type RoomRepositoryImpl struct {
+	*BaseRepository[models.Room]
+}
+
+// NewRoomRepository creates a new room repository
+func NewRoomRepository(collection *mongo.Collection) RoomRepository {
+	return &RoomRepositoryImpl{
+		BaseRepository: NewBaseRepository[models.Room](collection),
+	}
+}
+
+// AddParticipant adds a participant to a room unless it already has maxParticipants.
+// It returns false if the room is full.
+func (r *RoomRepositoryImpl) AddParticipant(ctx context.Context, id string, participant models.RoomParticipant, maxParticipants int) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid room ID format: %v", err)
+	}
+
+	filter := bson.M{
+		"_id": objectID,
+		fmt.Sprintf("participants.%d", maxParticipants-1): bson.M{"$exists": false},
+	}
+	update := bson.M{"$push": bson.M{"participants": participant}}
+	result, err := r.BaseRepository.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount == 1, nil
+}
+
+// SetParticipantAnswers stores a participant's answers. Each participant can only answer once:
+// it returns false if the participant has already answered.
+func (r *RoomRepositoryImpl) SetParticipantAnswers(ctx context.Context, id string, participantID primitive.ObjectID, answers []models.PlayerAnswer, answeredAt time.Time) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid room ID format: %v", err)
+	}
+
+	filter := bson.M{
+		"_id": objectID,
+		"participants": bson.M{"$elemMatch": bson.M{
+			"id":         participantID,
+			"answeredAt": bson.M{"$exists": false},
+		}},
+	}
+	update := bson.M{"$set": bson.M{
+		"participants.$.answers":    answers,
+		"participants.$.answeredAt": answeredAt,
+	}}
+	result, err := r.BaseRepository.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount == 1, nil
+}
@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"get-to-know-game-go/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ScoreDistributionRepositoryImpl implements ScoreDistributionRepository
+type ScoreDistributionRepositoryImpl struct {
+	*BaseRepository[models.ScoreDistribution]
+}
+
+// NewScoreDistributionRepository creates a new score distribution repository
+func NewScoreDistributionRepository(collection *mongo.Collection) ScoreDistributionRepository {
+	return &ScoreDistributionRepositoryImpl{
+		BaseRepository: NewBaseRepository[models.ScoreDistribution](collection),
+	}
+}
+
+// GetByKey retrieves the distribution stored under key
+func (r *ScoreDistributionRepositoryImpl) GetByKey(ctx context.Context, key string) (models.ScoreDistribution, error) {
+	var distribution models.ScoreDistribution
+	err := r.BaseRepository.collection.FindOne(ctx, bson.M{"_id": key}).Decode(&distribution)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return distribution, fmt.Errorf("score distribution not found")
+		}
+		return distribution, err
+	}
+
+	return distribution, nil
+}
+
+// Increment adds delta sessions with score to the distribution under key, creating it if needed
+func (r *ScoreDistributionRepositoryImpl) Increment(ctx context.Context, key string, score int, delta int) error {
+	update := bson.M{
+		"$inc": bson.M{fmt.Sprintf("counts.%d", score): delta, "total": delta},
+		"$set": bson.M{"updatedAt": time.Now().UTC()},
+	}
+	_, err := r.BaseRepository.collection.UpdateOne(ctx, bson.M{"_id": key}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// InsertMissing stores the distributions whose key has none yet. Stored distributions are left
+// as they are, so increments made while the distributions were being built are kept.
+func (r *ScoreDistributionRepositoryImpl) InsertMissing(ctx context.Context, distributions []models.ScoreDistribution) error {
+	if len(distributions) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(distributions))
+	for _, distribution := range distributions {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": distribution.Key}).
+			SetUpdate(bson.M{"$setOnInsert": bson.M{
+				"counts":    distribution.Counts,
+				"total":     distribution.Total,
+				"updatedAt": distribution.UpdatedAt,
+			}}).
+			SetUpsert(true))
+	}
+
+	_, err := r.BaseRepository.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// Count returns the number of stored distributions
+func (r *ScoreDistributionRepositoryImpl) Count(ctx context.Context) (int64, error) {
+	return r.BaseRepository.collection.CountDocuments(ctx, bson.M{})
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"get-to-know-game-go/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SectionRepositoryImpl implements SectionRepository
+type SectionRepositoryImpl struct {
+	*BaseRepository[models.Section]
+}
+
+// NewSectionRepository creates a new section repository
+func NewSectionRepository(collection *mongo.Collection) SectionRepository {
+	return &SectionRepositoryImpl{
+		BaseRepository: NewBaseRepository[models.Section](collection),
+	}
+}
+
+// EnsureIndexes creates the unique section key index
+func (r *SectionRepositoryImpl) EnsureIndexes(ctx context.Context) error {
+	_, err := r.BaseRepository.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "key", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// Update replaces a section, so a description or icon emptied in entity is cleared too
+func (r *SectionRepositoryImpl) Update(ctx context.Context, id string, entity models.Section) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid ID format: %v", err)
+	}
+
+	entity.ID = objectID
+	result, err := r.BaseRepository.collection.ReplaceOne(ctx, bson.M{"_id": objectID}, entity)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("document not found")
+	}
+
+	return nil
+}
+
+// GetAllOrdered retrieves every section in display order
+func (r *SectionRepositoryImpl) GetAllOrdered(ctx context.Context) ([]models.Section, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "displayOrder", Value: 1}, {Key: "name", Value: 1}})
+	cursor, err := r.BaseRepository.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	sections := []models.Section{}
+	if err := cursor.All(ctx, &sections); err != nil {
+		return nil, err
+	}
+
+	return sections, nil
+}
+
+// GetByKey retrieves a section by its normalized name
+func (r *SectionRepositoryImpl) GetByKey(ctx context.Context, key string) (models.Section, error) {
+	var section models.Section
+	err := r.BaseRepository.collection.FindOne(ctx, bson.M{"key": key}).Decode(&section)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return section, fmt.Errorf("section not found")
+		}
+		return section, err
+	}
+
+	return section, nil
+}
+
+// SetWeight sets the scoring weight of a section, or clears it when weight is nil
+func (r *SectionRepositoryImpl) SetWeight(ctx context.Context, id primitive.ObjectID, weight *float64) error {
+	update := bson.M{"$unset": bson.M{"weight": ""}}
+	if weight != nil {
+		update = bson.M{"$set": bson.M{"weight": *weight}}
+	}
+
+	result, err := r.BaseRepository.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("section not found")
+	}
+
+	return nil
+}
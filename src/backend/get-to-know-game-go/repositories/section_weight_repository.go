@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"get-to-know-game-go/models"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SectionWeightRepositoryImpl implements SectionWeightRepository
+type SectionWeightRepositoryImpl struct {
+	*BaseRepository[models.SectionWeight]
+}
+
+// NewSectionWeightRepository creates a new section weight repository
+func NewSectionWeightRepository(collection *mongo.Collection) SectionWeightRepository {
+	return &SectionWeightRepositoryImpl{
+		BaseRepository: NewBaseRepository[models.SectionWeight](collection),
+	}
+}
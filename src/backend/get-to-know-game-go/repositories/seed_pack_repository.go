@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"get-to-know-game-go/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SeedPackRepositoryImpl implements SeedPackRepository
+type SeedPackRepositoryImpl struct {
+	*BaseRepository[models.AppliedSeedPack]
+}
+
+// NewSeedPackRepository creates a new seed pack repository
+func NewSeedPackRepository(collection *mongo.Collection) SeedPackRepository {
+	return &SeedPackRepositoryImpl{
+		BaseRepository: NewBaseRepository[models.AppliedSeedPack](collection),
+	}
+}
+
+// GetByName retrieves the applied version of the named pack
+func (r *SeedPackRepositoryImpl) GetByName(ctx context.Context, name string) (models.AppliedSeedPack, error) {
+	var pack models.AppliedSeedPack
+	err := r.BaseRepository.collection.FindOne(ctx, bson.M{"_id": name}).Decode(&pack)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return pack, fmt.Errorf("seed pack not found")
+		}
+		return pack, err
+	}
+
+	return pack, nil
+}
+
+// Record stores the version of a pack that was just applied
+func (r *SeedPackRepositoryImpl) Record(ctx context.Context, pack models.AppliedSeedPack) error {
+	_, err := r.BaseRepository.collection.ReplaceOne(ctx, bson.M{"_id": pack.Name}, pack, options.Replace().SetUpsert(true))
+	return err
+}
@@ -0,0 +1,9 @@
+// Package seeds embeds the seed packs of default questions shipped with the backend
+package seeds
+
+import "embed"
+
+// Packs holds the embedded seed pack files
+//
+//go:embed *.yaml
+var Packs embed.FS
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AnswerFrequencyService keeps how often each response is given to each bank question,
+// which tells how rare a shared answer is
+type AnswerFrequencyService struct {
+	frequencyRepo repositories.AnswerFrequencyRepository
+	sessionRepo   repositories.GameSessionRepository
+}
+
+// NewAnswerFrequencyService creates a new answer frequency service
+func NewAnswerFrequencyService(frequencyRepo repositories.AnswerFrequencyRepository, sessionRepo repositories.GameSessionRepository) *AnswerFrequencyService {
+	return &AnswerFrequencyService{
+		frequencyRepo: frequencyRepo,
+		sessionRepo:   sessionRepo,
+	}
+}
+
+// Record counts both players' answers of a completed session. Custom questions are left
+// out since nobody else can answer them.
+func (s *AnswerFrequencyService) Record(ctx context.Context, session models.GameSession) error {
+	customIDs := make(map[primitive.ObjectID]bool, len(session.CustomQuestions))
+	for _, question := range session.CustomQuestions {
+		customIDs[question.ID] = true
+	}
+
+	answers := []models.PlayerAnswer{}
+	for _, answer := range append(append([]models.PlayerAnswer{}, session.Player1Answers...), *session.Player2Answers...) {
+		if !customIDs[answer.QuestionID] {
+			answers = append(answers, answer)
+		}
+	}
+
+	return s.frequencyRepo.Increment(ctx, answers)
+}
+
+// Backfill builds the frequency table from every scored session when it is empty,
+// covering sessions completed before frequencies were kept. It runs before the server
+// listens; frequencies another instance has started meanwhile are not overwritten.
+func (s *AnswerFrequencyService) Backfill(ctx context.Context) error {
+	count, err := s.frequencyRepo.Count(ctx)
+	if err != nil || count > 0 {
+		return err
+	}
+
+	answerCounts, err := s.sessionRepo.AnswerCounts(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	frequencies := make(map[primitive.ObjectID]*models.AnswerFrequency)
+	for _, answerCount := range answerCounts {
+		frequency, exists := frequencies[answerCount.QuestionID]
+		if !exists {
+			frequency = &models.AnswerFrequency{QuestionID: answerCount.QuestionID, Counts: make(map[string]int), UpdatedAt: now}
+			frequencies[answerCount.QuestionID] = frequency
+		}
+		frequency.Counts[answerCount.Response] += answerCount.Count
+		frequency.Total += answerCount.Count
+	}
+
+	result := make([]models.AnswerFrequency, 0, len(frequencies))
+	for _, frequency := range frequencies {
+		result = append(result, *frequency)
+	}
+	return s.frequencyRepo.InsertMissing(ctx, result)
+}
+
+// Frequencies returns the answer frequencies of questions by question ID.
+// Questions nobody has answered yet are missing from the result.
+func (s *AnswerFrequencyService) Frequencies(ctx context.Context, questions []models.Question) (map[string]models.AnswerFrequency, error) {
+	ids := make([]primitive.ObjectID, 0, len(questions))
+	for _, question := range questions {
+		ids = append(ids, question.ID)
+	}
+
+	frequencies, err := s.frequencyRepo.GetByQuestionIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	frequencyMap := make(map[string]models.AnswerFrequency, len(frequencies))
+	for _, frequency := range frequencies {
+		frequencyMap[frequency.QuestionID.Hex()] = frequency
+	}
+	return frequencyMap, nil
+}
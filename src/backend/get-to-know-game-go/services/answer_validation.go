@@ -0,0 +1,63 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"get-to-know-game-go/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Answer validation errors, shared by sessions, broadcasts and rooms
+var (
+	ErrUnknownQuestion     = errors.New("answer to unknown question")
+	ErrDuplicateAnswer     = errors.New("duplicate answer to question")
+	ErrInvalidResponse     = errors.New("invalid response")
+	ErrPredictionRequired  = errors.New("each answer must include a valid prediction")
+	ErrInvalidDealbreaker  = errors.New("only a positive or negative answer can be a dealbreaker")
+	ErrTooManyDealbreakers = errors.New("too many dealbreakers")
+	ErrUnansweredQuestions = errors.New("all questions must be answered")
+)
+
+// validateAnswerSet checks that answers contain exactly one response per question, valid on
+// that question's scale. With requirePredictions every answer must also carry a valid guess.
+func validateAnswerSet(questions []models.Question, answers []models.PlayerAnswer, requirePredictions bool) error {
+	expected := make(map[primitive.ObjectID]string, len(questions))
+	for _, question := range questions {
+		expected[question.ID] = question.Scale()
+	}
+
+	seen := make(map[primitive.ObjectID]bool, len(answers))
+	dealbreakers := 0
+	for _, answer := range answers {
+		scale, exists := expected[answer.QuestionID]
+		if !exists {
+			return fmt.Errorf("%w %s", ErrUnknownQuestion, answer.QuestionID.Hex())
+		}
+		if seen[answer.QuestionID] {
+			return fmt.Errorf("%w %s", ErrDuplicateAnswer, answer.QuestionID.Hex())
+		}
+		if !models.IsValidScaleResponse(scale, answer.Response) {
+			return fmt.Errorf("%w %q for question %s", ErrInvalidResponse, answer.Response, answer.QuestionID.Hex())
+		}
+		if requirePredictions && !models.IsValidScaleResponse(scale, answer.Prediction) {
+			return ErrPredictionRequired
+		}
+		if answer.Dealbreaker {
+			if !models.IsPositiveResponse(answer.Response) && !models.IsNegativeResponse(answer.Response) {
+				return ErrInvalidDealbreaker
+			}
+			if dealbreakers++; dealbreakers > models.MaxDealbreakers {
+				return ErrTooManyDealbreakers
+			}
+		}
+		seen[answer.QuestionID] = true
+	}
+
+	if len(seen) != len(expected) {
+		return ErrUnansweredQuestions
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"get-to-know-game-go/models"
+)
+
+var (
+	// ErrInvalidAudience is returned for an audience that is not one of the audience ratings
+	ErrInvalidAudience = errors.New("invalid audience")
+	// ErrNoMatchingQuestions is returned when an audience and tag filter leaves no questions to play
+	ErrNoMatchingQuestions = errors.New("no questions match the audience and tags")
+)
+
+// AudienceService filters questions by audience rating and tags, and keeps the ratings excluded
+// on this deployment from ever being served
+type AudienceService struct {
+	excludedRatings map[string]bool
+}
+
+// NewAudienceService creates a new audience service that never serves questions rated excludedRatings
+func NewAudienceService(excludedRatings []string) *AudienceService {
+	excluded := make(map[string]bool, len(excludedRatings))
+	for _, rating := range excludedRatings {
+		excluded[rating] = true
+	}
+	return &AudienceService{
+		excludedRatings: excluded,
+	}
+}
+
+// Filter normalizes an audience and tags given by a client into a question filter
+func (s *AudienceService) Filter(audience string, tags []string) (models.QuestionFilter, error) {
+	audience = strings.ToLower(strings.TrimSpace(audience))
+	if audience != "" && !models.IsValidRating(audience) {
+		return models.QuestionFilter{}, ErrInvalidAudience
+	}
+	return models.QuestionFilter{Audience: audience, Tags: models.NormalizeTags(tags)}, nil
+}
+
+// Allowed reports whether the question's rating may be served on this deployment
+func (s *AudienceService) Allowed(question models.Question) bool {
+	return !s.excludedRatings[question.AudienceRating()]
+}
+
+// Apply returns the questions matching filter whose rating may be served on this deployment
+func (s *AudienceService) Apply(questions []models.Question, filter models.QuestionFilter) []models.Question {
+	if len(s.excludedRatings) == 0 && filter.IsEmpty() {
+		return questions
+	}
+
+	kept := make([]models.Question, 0, len(questions))
+	for _, question := range questions {
+		if s.Allowed(question) && filter.Matches(question) {
+			kept = append(kept, question)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MaxBroadcastPartners caps how many partners one broadcast can invite
+const MaxBroadcastPartners = 10
+
+var (
+	// ErrPartnerNameRequired is returned when a broadcast names no partners
+	ErrPartnerNameRequired = errors.New("at least one partner name is required")
+	// ErrTooManyPartners is returned when a broadcast names more than MaxBroadcastPartners partners
+	ErrTooManyPartners = errors.New("too many partners")
+	// ErrBroadcastEmpty is returned when answering a broadcast that has no invites
+	ErrBroadcastEmpty = errors.New("broadcast has no invites")
+)
+
+// BroadcastService sends one game to several partners and compares player 1 against each of them
+type BroadcastService struct {
+	broadcastRepo          repositories.BroadcastRepository
+	sessionRepo            repositories.GameSessionRepository
+	playerRepo             repositories.PlayerRepository
+	sessionQuestionService *SessionQuestionService
+	sessionScoringService  *SessionScoringService
+	compatibilityService   *CompatibilityService
+	tokenService           *TokenService
+	joinCodeService        *JoinCodeService
+	sessionTTL             time.Duration
+}
+
+// NewBroadcastService creates a new broadcast service
+func NewBroadcastService(
+	broadcastRepo repositories.BroadcastRepository,
+	sessionRepo repositories.GameSessionRepository,
+	playerRepo repositories.PlayerRepository,
+	sessionQuestionService *SessionQuestionService,
+	sessionScoringService *SessionScoringService,
+	compatibilityService *CompatibilityService,
+	tokenService *TokenService,
+	joinCodeService *JoinCodeService,
+	sessionTTL time.Duration,
+) *BroadcastService {
+	return &BroadcastService{
+		broadcastRepo:          broadcastRepo,
+		sessionRepo:            sessionRepo,
+		playerRepo:             playerRepo,
+		sessionQuestionService: sessionQuestionService,
+		sessionScoringService:  sessionScoringService,
+		compatibilityService:   compatibilityService,
+		tokenService:           tokenService,
+		joinCodeService:        joinCodeService,
+		sessionTTL:             sessionTTL,
+	}
+}
+
+// CreateBroadcast creates player 1 and one game session per named partner.
+// It returns the broadcast with player 1's secret token, which is valid on every session.
+func (s *BroadcastService) CreateBroadcast(ctx context.Context, req models.CreateBroadcastRequest) (models.Broadcast, string, error) {
+	partnerNames := make([]string, 0, len(req.PartnerNames))
+	for _, name := range req.PartnerNames {
+		if name = strings.TrimSpace(name); name != "" {
+			partnerNames = append(partnerNames, name)
+		}
+	}
+	if len(partnerNames) == 0 {
+		return models.Broadcast{}, "", ErrPartnerNameRequired
+	}
+	if len(partnerNames) > MaxBroadcastPartners {
+		return models.Broadcast{}, "", ErrTooManyPartners
+	}
+
+	customQuestions, err := s.sessionQuestionService.BuildCustomQuestions(req.CustomQuestions)
+	if err != nil {
+		return models.Broadcast{}, "", err
+	}
+
+	filter, err := s.sessionQuestionService.QuestionFilter(req.Audience, req.Tags)
+	if err != nil {
+		return models.Broadcast{}, "", err
+	}
+
+	pack, err := s.sessionQuestionService.PinPack(ctx, req.Pack, filter)
+	if err != nil {
+		return models.Broadcast{}, "", err
+	}
+
+	// Player 1 answers once for every partner, so all sessions share one order
+	shuffle, shuffleSeed, err := s.sessionQuestionService.NewShuffle(req.Shuffle)
+	if err != nil {
+		return models.Broadcast{}, "", err
+	}
+
+	player1, err := s.playerRepo.Create(ctx, models.Player{Name: req.Player1Name})
+	if err != nil {
+		return models.Broadcast{}, "", err
+	}
+
+	token, tokenHash, err := s.tokenService.GenerateToken()
+	if err != nil {
+		return models.Broadcast{}, "", err
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(s.sessionTTL)
+	broadcast := models.Broadcast{
+		ID:               primitive.NewObjectID(),
+		Player1ID:        player1.ID,
+		Player1TokenHash: tokenHash,
+		Player1Answers:   []models.PlayerAnswer{},
+		Invites:          []models.BroadcastInvite{},
+		CreatedAt:        now,
+	}
+
+	for _, partnerName := range partnerNames {
+		partnerName := partnerName
+		// The join code is issued first so that no invite session is stored without one
+		sessionID := primitive.NewObjectID()
+		joinCode, err := s.joinCodeService.Issue(ctx, sessionID, expiresAt)
+		if err != nil {
+			return models.Broadcast{}, "", err
+		}
+
+		session, err := s.sessionRepo.Create(ctx, models.GameSession{
+			ID:               sessionID,
+			Mode:             models.GameModeClassic,
+			Player1ID:        player1.ID,
+			Player1Answers:   []models.PlayerAnswer{},
+			Player2Name:      &partnerName,
+			Player1TokenHash: tokenHash,
+			CreatedAt:        &now,
+			ExpiresAt:        &expiresAt,
+			CustomQuestions:  customQuestions,
+			BroadcastID:      &broadcast.ID,
+			PackID:           pack.ID,
+			PackVersion:      pack.PublishedVersion,
+			Audience:         filter.Audience,
+			Tags:             filter.Tags,
+			Shuffle:          shuffle,
+			ShuffleSeed:      shuffleSeed,
+			JoinCode:         joinCode,
+		})
+		if err != nil {
+			if err := s.joinCodeService.Release(ctx, sessionID); err != nil {
+				log.Printf("Failed to release join code of session %s: %v", sessionID.Hex(), err)
+			}
+			return models.Broadcast{}, "", err
+		}
+
+		broadcast.Invites = append(broadcast.Invites, models.BroadcastInvite{
+			PartnerName: partnerName,
+			SessionID:   session.ID,
+			JoinCode:    joinCode,
+		})
+	}
+
+	createdBroadcast, err := s.broadcastRepo.Create(ctx, broadcast)
+	if err != nil {
+		return models.Broadcast{}, "", err
+	}
+
+	return createdBroadcast, token, nil
+}
+
+// GetBroadcast retrieves a broadcast by ID
+func (s *BroadcastService) GetBroadcast(ctx context.Context, id string) (models.Broadcast, error) {
+	return s.broadcastRepo.GetByID(ctx, id)
+}
+
+// SubmitAnswers stores player 1's answers on every invited session and scores
+// the sessions whose partner has already answered
+func (s *BroadcastService) SubmitAnswers(ctx context.Context, broadcast models.Broadcast, answers []models.PlayerAnswer) error {
+	if len(broadcast.Invites) == 0 {
+		return ErrBroadcastEmpty
+	}
+
+	// All sessions of a broadcast share the same question set
+	firstSession, err := s.sessionRepo.GetByID(ctx, broadcast.Invites[0].SessionID.Hex())
+	if err != nil {
+		return err
+	}
+	if err := s.sessionQuestionService.ValidateAnswers(ctx, firstSession, answers); err != nil {
+		return err
+	}
+
+	// Player 1's answers are locked once any partner's results are in
+	for _, invite := range broadcast.Invites {
+		session, err := s.sessionRepo.GetByID(ctx, invite.SessionID.Hex())
+		if err != nil {
+			return err
+		}
+		if session.IsComplete() {
+			return ErrSessionScored
+		}
+	}
+
+	if err := s.broadcastRepo.UpdateAnswers(ctx, broadcast.ID.Hex(), answers); err != nil {
+		return err
+	}
+
+	for _, invite := range broadcast.Invites {
+		sessionID := invite.SessionID.Hex()
+		updated, err := s.sessionRepo.UpdateAnswers(ctx, sessionID, broadcast.Player1ID.Hex(), answers)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return ErrSessionScored
+		}
+		if _, err := s.sessionScoringService.ScoreIfComplete(ctx, sessionID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Compare ranks every partner by compatibility with player 1, with a per-section breakdown.
+// Partners who haven't finished yet are listed after the ranked ones.
+func (s *BroadcastService) Compare(ctx context.Context, broadcast models.Broadcast) ([]models.BroadcastComparison, error) {
+	comparisons := make([]models.BroadcastComparison, 0, len(broadcast.Invites))
+	for _, invite := range broadcast.Invites {
+		session, err := s.sessionRepo.GetByID(ctx, invite.SessionID.Hex())
+		if err != nil {
+			return nil, err
+		}
+
+		comparison := models.BroadcastComparison{
+			PartnerName: invite.PartnerName,
+			SessionID:   invite.SessionID.Hex(),
+			Status:      models.BroadcastPartnerInvited,
+		}
+		if session.Player2ID != nil {
+			comparison.Status = models.BroadcastPartnerJoined
+		}
+
+		if session.IsComplete() {
+			comparison.Status = models.BroadcastPartnerAnswered
+			comparison.CompatibilityScore = session.CompatibilityScore
+			comparison.Emoji = s.compatibilityService.ScoreEmoji(*session.CompatibilityScore)
+			comparison.Sections = session.SectionScores
+			if comparison.Sections == nil {
+				questions, err := s.sessionQuestionService.Questions(ctx, session)
+				if err != nil {
+					return nil, err
+				}
+				comparison.Sections = s.compatibilityService.CalculateSectionScores(session.Player1Answers, *session.Player2Answers, questions)
+			}
+		}
+
+		comparisons = append(comparisons, comparison)
+	}
+
+	sort.SliceStable(comparisons, func(i, j int) bool {
+		scoreI, scoreJ := comparisons[i].CompatibilityScore, comparisons[j].CompatibilityScore
+		if scoreI == nil || scoreJ == nil {
+			return scoreI != nil && scoreJ == nil
+		}
+		return *scoreI > *scoreJ
+	})
+
+	return comparisons, nil
+}
@@ -1,50 +1,217 @@
 package services
 
 import (
-	"fmt"
+	"math"
 
 	"get-to-know-game-go/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // CompatibilityService handles compatibility score calculations
-type CompatibilityService struct{}
+type CompatibilityService struct {
+	scorers *ScorerRegistry
+}
 
-// NewCompatibilityService creates a new compatibility service
-func NewCompatibilityService() *CompatibilityService {
-	return &CompatibilityService{}
+// NewCompatibilityService creates a new compatibility service scoring with the given strategies
+func NewCompatibilityService(scorers *ScorerRegistry) *CompatibilityService {
+	return &CompatibilityService{scorers: scorers}
 }
 
-// CalculateScore calculates the compatibility score between two players
+// CalculateScore calculates the compatibility score between two players with the default strategy
 func (s *CompatibilityService) CalculateScore(player1Answers, player2Answers []models.PlayerAnswer) (int, error) {
-	if len(player1Answers) != len(player2Answers) {
-		return 0, fmt.Errorf("both players must answer the same number of questions")
+	score, _, err := s.ScoreWith(DefaultScorerName, ScoreInput{
+		Player1Answers: player1Answers,
+		Player2Answers: player2Answers,
+	})
+	return score, err
+}
+
+// ScoreWith calculates the compatibility score with the named strategy and returns the scorer used
+func (s *CompatibilityService) ScoreWith(scorerName string, input ScoreInput) (int, Scorer, error) {
+	scorer, err := s.scorers.Get(scorerName)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	score, err := scorer.Score(input)
+	if err != nil {
+		return 0, nil, err
 	}
+	return score, scorer, nil
+}
+
+// HasScorer reports whether name is an available scoring strategy
+func (s *CompatibilityService) HasScorer(name string) bool {
+	_, err := s.scorers.Get(name)
+	return err == nil
+}
 
-	matches := 0
-	totalQuestions := len(player1Answers)
+// ScorerNames returns the names of the available scoring strategies
+func (s *CompatibilityService) ScorerNames() []string {
+	return s.scorers.Names()
+}
 
-	// Create a map of player2 answers for quick lookup
+// CalculateSectionScores calculates the compatibility within each section of questions.
+// Sections keep the order in which they first appear in questions.
+func (s *CompatibilityService) CalculateSectionScores(player1Answers, player2Answers []models.PlayerAnswer, questions []models.Question) []models.SectionScore {
+	player1AnswerMap := make(map[string]string)
+	for _, answer := range player1Answers {
+		player1AnswerMap[answer.QuestionID.Hex()] = answer.Response
+	}
 	player2AnswerMap := make(map[string]string)
 	for _, answer := range player2Answers {
 		player2AnswerMap[answer.QuestionID.Hex()] = answer.Response
 	}
 
-	// Count matches where both players said "Yay!" or "I don't care!"
-	for _, player1Answer := range player1Answers {
-		player2Response, exists := player2AnswerMap[player1Answer.QuestionID.Hex()]
+	sectionScores := []models.SectionScore{}
+	credits := []float64{}
+	sectionIndex := make(map[string]int)
+	for _, question := range questions {
+		player1Response, answered1 := player1AnswerMap[question.ID.Hex()]
+		player2Response, answered2 := player2AnswerMap[question.ID.Hex()]
+		if !answered1 || !answered2 {
+			continue
+		}
+
+		index, exists := sectionIndex[question.Section]
 		if !exists {
+			index = len(sectionScores)
+			sectionIndex[question.Section] = index
+			sectionScores = append(sectionScores, models.SectionScore{Section: question.Section})
+			credits = append(credits, 0)
+		}
+
+		sectionScores[index].Questions++
+		credits[index] += answerCredit(player1Response, player2Response)
+		if s.IsSharedAnswer(player1Response, player2Response) {
+			sectionScores[index].Matches++
+		}
+	}
+
+	for i := range sectionScores {
+		sectionScores[i].Score = percentage(credits[i], float64(sectionScores[i].Questions))
+	}
+
+	return sectionScores
+}
+
+// ExplainScore itemizes the spec formula question by question: whether each question counted,
+// why, and how many percentage points it contributed. Questions count their weight, or
+// models.DefaultWeight when weights is nil.
+func (s *CompatibilityService) ExplainScore(player1Answers, player2Answers []models.PlayerAnswer, questions []models.Question, weights map[string]float64) ([]models.ScoreExplanationItem, float64) {
+	player1AnswerMap := make(map[string]models.PlayerAnswer)
+	for _, answer := range player1Answers {
+		player1AnswerMap[answer.QuestionID.Hex()] = answer
+	}
+	player2AnswerMap := make(map[string]models.PlayerAnswer)
+	for _, answer := range player2Answers {
+		player2AnswerMap[answer.QuestionID.Hex()] = answer
+	}
+
+	input := ScoreInput{Weights: weights}
+	items := []models.ScoreExplanationItem{}
+	credits := []float64{}
+	totalWeight := 0.0
+	for _, question := range questions {
+		questionID := question.ID.Hex()
+		player1Answer, answered1 := player1AnswerMap[questionID]
+		player2Answer, answered2 := player2AnswerMap[questionID]
+		if !answered1 || !answered2 {
 			continue
 		}
 
-		// Only count matches where both said "Yay!" or "I don't care!"
-		if (player1Answer.Response == models.Yay || player1Answer.Response == models.DontCare) &&
-			(player2Response == models.Yay || player2Response == models.DontCare) &&
-			player1Answer.Response == player2Response {
-			matches++
+		weight := input.weight(questionID)
+		totalWeight += weight
+		credit := answerCredit(player1Answer.Response, player2Answer.Response)
+		credits = append(credits, credit)
+		items = append(items, models.ScoreExplanationItem{
+			QuestionID:   questionID,
+			Section:      question.Section,
+			QuestionText: question.QuestionText,
+			Counted:      credit > 0,
+			Reason:       explanationReason(player1Answer.Response, player2Answer.Response),
+			Weight:       weight,
+			Dealbreaker: (player1Answer.Dealbreaker || player2Answer.Dealbreaker) &&
+				models.IsOppositeResponse(player1Answer.Response, player2Answer.Response),
+		})
+	}
+
+	totalPoints := 0.0
+	for i := range items {
+		if totalWeight > 0 {
+			items[i].Points = math.Round(credits[i]*items[i].Weight/totalWeight*10000) / 100
+		}
+		totalPoints += items[i].Points
+	}
+
+	return items, math.Round(totalPoints*100) / 100
+}
+
+// explanationReason names why a pair of responses did or didn't count
+func explanationReason(player1Response, player2Response string) string {
+	_, likert1 := models.LikertPoint(player1Response)
+	_, likert2 := models.LikertPoint(player2Response)
+	switch {
+	case models.IsNegativeResponse(player1Response) && models.IsNegativeResponse(player2Response):
+		return models.ReasonSharedNay
+	case likert1 && likert2:
+		return models.ReasonLikert
+	case player1Response != player2Response:
+		return models.ReasonMismatch
+	case player1Response == models.Yay:
+		return models.ReasonBothYay
+	case player1Response == models.DontCare:
+		return models.ReasonBothDontCare
+	default:
+		return models.ReasonBothYes
+	}
+}
+
+// FindDealbreakers returns the answers either player flagged as a dealbreaker that the
+// partner answered the opposite way, player 1's first
+func (s *CompatibilityService) FindDealbreakers(player1ID, player2ID primitive.ObjectID, player1Answers, player2Answers []models.PlayerAnswer) []models.TriggeredDealbreaker {
+	dealbreakers := []models.TriggeredDealbreaker{}
+	dealbreakers = append(dealbreakers, contradictedDealbreakers(player1ID, player1Answers, player2Answers)...)
+	dealbreakers = append(dealbreakers, contradictedDealbreakers(player2ID, player2Answers, player1Answers)...)
+	return dealbreakers
+}
+
+// contradictedDealbreakers returns the dealbreakers in answers that partnerAnswers contradict
+func contradictedDealbreakers(playerID primitive.ObjectID, answers, partnerAnswers []models.PlayerAnswer) []models.TriggeredDealbreaker {
+	partnerAnswerMap := make(map[primitive.ObjectID]string)
+	for _, answer := range partnerAnswers {
+		partnerAnswerMap[answer.QuestionID] = answer.Response
+	}
+
+	dealbreakers := []models.TriggeredDealbreaker{}
+	for _, answer := range answers {
+		partnerResponse, exists := partnerAnswerMap[answer.QuestionID]
+		if answer.Dealbreaker && exists && models.IsOppositeResponse(answer.Response, partnerResponse) {
+			dealbreakers = append(dealbreakers, models.TriggeredDealbreaker{
+				QuestionID: answer.QuestionID,
+				PlayerID:   playerID,
+			})
 		}
 	}
+	return dealbreakers
+}
+
+// ScoreEmoji returns the emoji tier for a compatibility score
+func (s *CompatibilityService) ScoreEmoji(score int) string {
+	switch {
+	case score >= 80:
+		return "❤️"
+	case score >= 50:
+		return "🙂"
+	case score >= 20:
+		return "😬"
+	default:
+		return "💀"
+	}
+}
 
-	// Calculate percentage and round to nearest whole number
-	score := int(float64(matches)/float64(totalQuestions)*100 + 0.5)
-	return score, nil
+// IsSharedAnswer reports whether two responses form a shared answer that counts towards the score
+func (s *CompatibilityService) IsSharedAnswer(player1Response, player2Response string) bool {
+	return isSharedAnswer(player1Response, player2Response)
 }
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxQuestionTextLength is the longest text, in characters, a player-written question can have
+	MaxQuestionTextLength = 100
+	// MaxSectionLength is the longest section name, in characters, a player-written question can have
+	MaxSectionLength = 40
+)
+
+var (
+	// ErrQuestionTextRequired is returned for a question without text
+	ErrQuestionTextRequired = errors.New("question text is required")
+	// ErrQuestionTextTooLong is returned for a question longer than MaxQuestionTextLength
+	ErrQuestionTextTooLong = errors.New("question text is too long")
+	// ErrSectionNameTooLong is returned for a section name longer than MaxSectionLength
+	ErrSectionNameTooLong = errors.New("section name is too long")
+	// ErrInappropriateLanguage is returned for content containing a blocked word
+	ErrInappropriateLanguage = errors.New("question contains inappropriate language")
+)
+
+// blockedWords is a small list of words that may not appear in player-written content
+var blockedWords = map[string]bool{
+	"ass": true, "asshole": true, "bastard": true, "bitch": true, "bollocks": true,
+	"crap": true, "cunt": true, "damn": true, "dick": true, "fuck": true,
+	"fucking": true, "motherfucker": true, "piss": true, "prick": true, "pussy": true,
+	"shit": true, "slut": true, "twat": true, "wanker": true, "whore": true,
+}
+
+// leetReplacer undoes common character substitutions used to dodge the word list
+var leetReplacer = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s")
+
+// ContentValidator checks player-written content such as custom questions
+type ContentValidator struct{}
+
+// NewContentValidator creates a new content validator
+func NewContentValidator() *ContentValidator {
+	return &ContentValidator{}
+}
+
+// ValidateQuestion checks the length of a question and its section and rejects profanity
+func (v *ContentValidator) ValidateQuestion(section, questionText string) error {
+	if strings.TrimSpace(questionText) == "" {
+		return ErrQuestionTextRequired
+	}
+	if utf8.RuneCountInString(questionText) > MaxQuestionTextLength {
+		return ErrQuestionTextTooLong
+	}
+	if utf8.RuneCountInString(section) > MaxSectionLength {
+		return ErrSectionNameTooLong
+	}
+	if v.ContainsProfanity(questionText) || v.ContainsProfanity(section) {
+		return ErrInappropriateLanguage
+	}
+	return nil
+}
+
+// ContainsProfanity reports whether any word of text is on the blocked word list
+func (v *ContentValidator) ContainsProfanity(text string) bool {
+	normalized := leetReplacer.Replace(strings.ToLower(text))
+	words := strings.FieldsFunc(normalized, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		if blockedWords[word] {
+			return true
+		}
+	}
+	return false
+}
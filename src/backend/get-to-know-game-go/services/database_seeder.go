@@ -2,68 +2,312 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
 	"log"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
 
 	"get-to-know-game-go/models"
 	"get-to-know-game-go/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"gopkg.in/yaml.v3"
 )
 
-// DatabaseSeeder handles seeding the database with initial data
+// DatabaseSeeder handles seeding the database with the questions of the seed packs
 type DatabaseSeeder struct {
-	questionRepo repositories.QuestionRepository
+	questionRepo   repositories.QuestionRepository
+	seedPackRepo   repositories.SeedPackRepository
+	sectionService *SectionService
+	packService    *PackService
+	embeddedPacks  fs.FS
+	seedDir        string
 }
 
-// NewDatabaseSeeder creates a new database seeder
-func NewDatabaseSeeder(questionRepo repositories.QuestionRepository) *DatabaseSeeder {
+// NewDatabaseSeeder creates a new database seeder reading the packs embedded in embeddedPacks
+// and, when seedDir is set, the pack files of that directory as well
+func NewDatabaseSeeder(questionRepo repositories.QuestionRepository, seedPackRepo repositories.SeedPackRepository, sectionService *SectionService, packService *PackService, embeddedPacks fs.FS, seedDir string) *DatabaseSeeder {
 	return &DatabaseSeeder{
-		questionRepo: questionRepo,
+		questionRepo:   questionRepo,
+		seedPackRepo:   seedPackRepo,
+		sectionService: sectionService,
+		packService:    packService,
+		embeddedPacks:  embeddedPacks,
+		seedDir:        seedDir,
+	}
+}
+
+// SeedQuestions applies every seed pack whose version is newer than the one last applied.
+// Questions are upserted by key, so seeding is safe to repeat. Questions an admin edited since
+// they were seeded are kept as they are unless force is set, which also re-applies packs
+// whose version was already applied. Seeded questions join the default pack's draft. When seeding
+// changed any of them, a version of the default pack is published with just those questions added
+// to the published one, so new games get them while other draft edits wait for an admin to publish.
+func (s *DatabaseSeeder) SeedQuestions(ctx context.Context, force bool) ([]models.SeedResult, error) {
+	packs, err := s.loadPacks()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.SeedResult, 0, len(packs))
+	var changed []string
+	var seededIDs []primitive.ObjectID
+	for _, pack := range packs {
+		result, ids, err := s.seedPack(ctx, pack, force)
+		if err != nil {
+			return results, fmt.Errorf("seed pack %s: %w", pack.Name, err)
+		}
+		if result.Skipped {
+			log.Printf("Seed pack %s v%d already applied, skipping...", pack.Name, pack.Version)
+		} else {
+			log.Printf("Applied seed pack %s v%d: %d created, %d updated, %d unchanged, %d kept as edited",
+				pack.Name, pack.Version, result.Created, result.Updated, result.Unchanged, result.Kept)
+		}
+		if result.Created > 0 || result.Updated > 0 {
+			changed = append(changed, fmt.Sprintf("%s v%d", pack.Name, pack.Version))
+		}
+		seededIDs = append(seededIDs, ids...)
+		results = append(results, result)
+	}
+
+	if len(changed) > 0 {
+		seeded, err := s.questionRepo.GetByIDs(ctx, seededIDs)
+		if err != nil {
+			return results, err
+		}
+		version, err := s.packService.PublishWith(ctx, "", "Seed packs "+strings.Join(changed, ", "), seeded)
+		if err != nil {
+			return results, fmt.Errorf("publish default pack: %w", err)
+		}
+		log.Printf("Published version %d of the default pack with the seeded questions", version.Version)
 	}
+	return results, nil
 }
 
-// SeedQuestions seeds the database with sample questions if the collection is empty
-func (s *DatabaseSeeder) SeedQuestions(ctx context.Context) error {
-	// Check if questions already exist
-	existingQuestions, err := s.questionRepo.GetAll(ctx)
+// seedPack upserts the questions of one pack and records its version. It returns the IDs of the
+// questions it created or updated.
+func (s *DatabaseSeeder) seedPack(ctx context.Context, pack models.SeedPack, force bool) (models.SeedResult, []primitive.ObjectID, error) {
+	result := models.SeedResult{Pack: pack.Name, Version: pack.Version}
+
+	applied, err := s.seedPackRepo.GetByName(ctx, pack.Name)
+	if err == nil && applied.Version >= pack.Version && !force {
+		result.Skipped = true
+		return result, nil, nil
+	}
+
+	existing, err := s.questionRepo.GetAll(ctx)
 	if err != nil {
-		return err
+		return result, nil, err
+	}
+	byKey := make(map[string]models.Question, len(existing))
+	byText := make(map[string]models.Question, len(existing))
+	for _, question := range existing {
+		if question.Key != "" {
+			byKey[question.Key] = question
+		}
+		byText[questionIdentity(question.Section, question.QuestionText)] = question
 	}
 
-	if len(existingQuestions) > 0 {
-		log.Println("Questions already seeded, skipping...")
-		return nil
+	var seededIDs []primitive.ObjectID
+	for _, record := range pack.Questions {
+		// Questions seeded before keys existed are adopted by their section and text
+		current, exists := byKey[record.Key]
+		if !exists {
+			current, exists = byText[questionIdentity(record.Section, record.QuestionText)]
+			exists = exists && current.Key == ""
+		}
+		if !exists {
+			current = models.Question{}
+		}
+
+		seeded := applyQuestionRecord(current, record)
+		seeded.SeedChecksum = seedChecksum(seeded)
+
+		if !exists {
+			id, err := s.saveQuestion(ctx, seeded, false)
+			if err != nil {
+				return result, nil, err
+			}
+			seededIDs = append(seededIDs, id)
+			result.Created++
+			continue
+		}
+
+		if questionUnchanged(current, seeded) && current.SeedChecksum == seeded.SeedChecksum {
+			result.Unchanged++
+			continue
+		}
+
+		if seededQuestionEdited(current, seeded) && !force {
+			// Keep the admin's version, but key it so later packs find it
+			if current.Key == "" {
+				current.Key = record.Key
+				if err := s.questionRepo.Update(ctx, current.ID.Hex(), current); err != nil {
+					return result, nil, err
+				}
+			}
+			result.Kept++
+			continue
+		}
+
+		id, err := s.saveQuestion(ctx, seeded, true)
+		if err != nil {
+			return result, nil, err
+		}
+		seededIDs = append(seededIDs, id)
+		result.Updated++
 	}
 
-	// Sample questions from the specification
-	questions := []models.Question{
-		// Food Section
-		{Section: "Food", QuestionText: "Pizza Diavola"},
-		{Section: "Food", QuestionText: "Sushi"},
-		{Section: "Food", QuestionText: "Pineapple on pizza"},
+	err = s.seedPackRepo.Record(ctx, models.AppliedSeedPack{
+		Name:      pack.Name,
+		Version:   pack.Version,
+		AppliedAt: time.Now().UTC(),
+	})
+	return result, seededIDs, err
+}
 
-		// Entertainment Section
-		{Section: "Entertainment", QuestionText: "Marvel movies"},
-		{Section: "Entertainment", QuestionText: "Anime"},
-		{Section: "Entertainment", QuestionText: "TikTok"},
+// saveQuestion links a seeded question to its section, creates or updates it and returns its ID
+func (s *DatabaseSeeder) saveQuestion(ctx context.Context, question models.Question, update bool) (primitive.ObjectID, error) {
+	section, err := s.sectionService.ResolveSection(ctx, question.Section)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	question.Section, question.SectionID = section.Name, section.ID
 
-		// Lifestyle Section
-		{Section: "Lifestyle", QuestionText: "Night owl"},
-		{Section: "Lifestyle", QuestionText: "Gym"},
-		{Section: "Lifestyle", QuestionText: "Reading books"},
+	if update {
+		return question.ID, s.questionRepo.Update(ctx, question.ID.Hex(), question)
+	}
+	created, err := s.questionRepo.Create(ctx, question)
+	return created.ID, err
+}
 
-		// Travel Section
-		{Section: "Travel", QuestionText: "Beach holidays"},
-		{Section: "Travel", QuestionText: "Camping"},
-		{Section: "Travel", QuestionText: "Visiting museums"},
+// loadPacks reads the embedded packs followed by those of the seed directory, ordered by name.
+// A pack in the seed directory replaces an embedded pack with the same name.
+func (s *DatabaseSeeder) loadPacks() ([]models.SeedPack, error) {
+	packsByName := make(map[string]models.SeedPack)
+	sources := []fs.FS{s.embeddedPacks}
+	if s.seedDir != "" {
+		sources = append(sources, os.DirFS(s.seedDir))
 	}
 
-	// Insert each question
-	for _, question := range questions {
-		_, err := s.questionRepo.Create(ctx, question)
+	for _, source := range sources {
+		files, err := fs.ReadDir(source, ".")
 		if err != nil {
-			return err
+			return nil, err
+		}
+		for _, file := range files {
+			extension := strings.ToLower(path.Ext(file.Name()))
+			if file.IsDir() || (extension != ".yaml" && extension != ".yml" && extension != ".json") {
+				continue
+			}
+
+			pack, err := readSeedPack(source, file.Name())
+			if err != nil {
+				return nil, err
+			}
+			packsByName[pack.Name] = pack
+		}
+	}
+
+	packs := make([]models.SeedPack, 0, len(packsByName))
+	for _, pack := range packsByName {
+		packs = append(packs, pack)
+	}
+	sort.Slice(packs, func(i, j int) bool {
+		return packs[i].Name < packs[j].Name
+	})
+	return packs, nil
+}
+
+// readSeedPack parses and validates a YAML or JSON pack file; every question needs a unique key
+func readSeedPack(source fs.FS, name string) (models.SeedPack, error) {
+	var pack models.SeedPack
+	data, err := fs.ReadFile(source, name)
+	if err != nil {
+		return pack, err
+	}
+	// JSON is valid YAML, so one decoder reads both
+	if err := yaml.Unmarshal(data, &pack); err != nil {
+		return pack, fmt.Errorf("%s: %w", name, err)
+	}
+
+	pack.Name = strings.TrimSpace(pack.Name)
+	if pack.Name == "" {
+		return pack, fmt.Errorf("%s: pack name is required", name)
+	}
+	if pack.Version < 1 {
+		return pack, fmt.Errorf("%s: pack version must be at least 1", name)
+	}
+
+	keys := make(map[string]bool, len(pack.Questions))
+	for i, record := range pack.Questions {
+		record = normalizeQuestionRecord(record)
+		if record.Key == "" {
+			return pack, fmt.Errorf("%s: question %d has no key", name, i+1)
+		}
+		if keys[record.Key] {
+			return pack, fmt.Errorf("%s: duplicate question key %s", name, record.Key)
+		}
+		if err := validateQuestionRecord(record); err != nil {
+			return pack, fmt.Errorf("%s: question %s: %w", name, record.Key, err)
 		}
+		if record.Pack != "" && record.Pack != models.DefaultPackKey {
+			return pack, fmt.Errorf("%s: question %s: seed packs only fill the default question pack", name, record.Key)
+		}
+		keys[record.Key] = true
+		pack.Questions[i] = record
 	}
+	return pack, nil
+}
 
-	log.Printf("Successfully seeded %d questions", len(questions))
-	return nil
+// seededQuestionEdited reports whether current was changed since a seed pack last wrote it.
+// A question no pack has written yet counts as edited when it differs from the seeded version.
+func seededQuestionEdited(current, seeded models.Question) bool {
+	if current.SeedChecksum == "" {
+		current.Key = seeded.Key
+		if len(current.Translations) == 0 {
+			current.Translations = seeded.Translations
+		}
+		return !questionUnchanged(current, seeded)
+	}
+	return seedChecksum(current) != current.SeedChecksum
+}
+
+// seedChecksum fingerprints the seeded content of a question
+func seedChecksum(question models.Question) string {
+	weight := ""
+	if question.Weight != nil {
+		weight = fmt.Sprint(*question.Weight)
+	}
+	fields := []string{
+		question.Key,
+		models.SectionKey(question.Section),
+		question.QuestionText,
+		question.Scale(),
+		weight,
+		fmt.Sprint(question.Order),
+	}
+	// Only fingerprinted when set, so questions seeded before tags, ratings and translations keep their checksum
+	if len(question.Tags) > 0 || question.Rating != "" {
+		fields = append(fields, strings.Join(question.Tags, ","), question.AudienceRating())
+	}
+	if len(question.Translations) > 0 {
+		languages := make([]string, 0, len(question.Translations))
+		for language := range question.Translations {
+			languages = append(languages, language)
+		}
+		sort.Strings(languages)
+		for _, language := range languages {
+			fields = append(fields, language, question.Translations[language].QuestionText)
+		}
+	}
+	content := strings.Join(fields, "\x00")
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:8])
 }
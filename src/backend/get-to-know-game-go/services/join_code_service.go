@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// joinCodeAlphabet leaves out characters that are easy to confuse: 0/O and 1/I
+	joinCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	joinCodeLength   = 6
+	// joinCodeAttempts is how many random codes are tried before giving up on collisions
+	joinCodeAttempts = 10
+)
+
+// JoinCodeService issues and resolves short join codes for sessions
+type JoinCodeService struct {
+	joinCodeRepo repositories.JoinCodeRepository
+}
+
+// NewJoinCodeService creates a new join code service
+func NewJoinCodeService(joinCodeRepo repositories.JoinCodeRepository) *JoinCodeService {
+	return &JoinCodeService{
+		joinCodeRepo: joinCodeRepo,
+	}
+}
+
+// Issue reserves a new unique code for the session that expires at expiresAt
+func (s *JoinCodeService) Issue(ctx context.Context, sessionID primitive.ObjectID, expiresAt time.Time) (string, error) {
+	return s.issue(ctx, models.JoinCode{SessionID: sessionID, ExpiresAt: expiresAt})
+}
+
+// IssueForRoom reserves a new unique code for a party room that expires at expiresAt
+func (s *JoinCodeService) IssueForRoom(ctx context.Context, roomID primitive.ObjectID, expiresAt time.Time) (string, error) {
+	return s.issue(ctx, models.JoinCode{RoomID: roomID, ExpiresAt: expiresAt})
+}
+
+// Resolve returns the active join code matching code
+func (s *JoinCodeService) Resolve(ctx context.Context, code string) (models.JoinCode, error) {
+	return s.joinCodeRepo.GetActiveByCode(ctx, NormalizeJoinCode(code))
+}
+
+// issue stores joinCode under a random code.
+// Collisions are detected by the unique index on the code and retried.
+func (s *JoinCodeService) issue(ctx context.Context, joinCode models.JoinCode) (string, error) {
+	for attempt := 0; attempt < joinCodeAttempts; attempt++ {
+		code, err := generateJoinCode()
+		if err != nil {
+			return "", err
+		}
+
+		joinCode.Code = code
+		_, err = s.joinCodeRepo.Create(ctx, joinCode)
+		if err == nil {
+			return code, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return "", err
+		}
+	}
+
+	return "", fmt.Errorf("failed to find a free join code after %d attempts", joinCodeAttempts)
+}
+
+// Release removes the join codes of a session
+func (s *JoinCodeService) Release(ctx context.Context, sessionID primitive.ObjectID) error {
+	return s.joinCodeRepo.DeleteBySession(ctx, sessionID)
+}
+
+// ReleaseRoom removes the join codes of a party room
+func (s *JoinCodeService) ReleaseRoom(ctx context.Context, roomID primitive.ObjectID) error {
+	return s.joinCodeRepo.DeleteByRoom(ctx, roomID)
+}
+
+// NormalizeJoinCode uppercases a code and strips spaces and dashes people type when sharing it
+func NormalizeJoinCode(code string) string {
+	code = strings.ToUpper(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.Join(strings.Fields(code), "")
+}
+
+// generateJoinCode returns a random code drawn from joinCodeAlphabet
+func generateJoinCode() (string, error) {
+	max := big.NewInt(int64(len(joinCodeAlphabet)))
+	code := make([]byte, joinCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = joinCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
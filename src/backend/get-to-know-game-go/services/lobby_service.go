@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrLobbyTicketFinished is returned when cancelling a ticket that is no longer waiting
+var ErrLobbyTicketFinished = errors.New("lobby ticket is no longer waiting")
+
+// LobbyService pairs waiting players at random into new game sessions
+type LobbyService struct {
+	store        repositories.LobbyStore
+	playerRepo   repositories.PlayerRepository
+	sessionRepo  repositories.GameSessionRepository
+	packService  *PackService
+	tokenService *TokenService
+	timeout      time.Duration
+	sessionTTL   time.Duration
+}
+
+// NewLobbyService creates a new lobby service
+func NewLobbyService(
+	store repositories.LobbyStore,
+	playerRepo repositories.PlayerRepository,
+	sessionRepo repositories.GameSessionRepository,
+	packService *PackService,
+	tokenService *TokenService,
+	timeout time.Duration,
+	sessionTTL time.Duration,
+) *LobbyService {
+	return &LobbyService{
+		store:        store,
+		playerRepo:   playerRepo,
+		sessionRepo:  sessionRepo,
+		packService:  packService,
+		tokenService: tokenService,
+		timeout:      timeout,
+		sessionTTL:   sessionTTL,
+	}
+}
+
+// Join queues a player and immediately tries to pair them.
+// It returns the ticket and the player's secret token, which also becomes their session token once matched.
+// Any ticket still waiting for the same client is cancelled, so a reloaded page doesn't queue twice.
+// Requests without a client ID are told apart by clientAddress, the address they came from, so a
+// player can't be paired with their own ticket from another tab.
+func (s *LobbyService) Join(ctx context.Context, req models.JoinLobbyRequest, clientAddress string) (models.LobbyTicket, string, error) {
+	if req.ClientID == "" && clientAddress != "" {
+		req.ClientID = "address:" + clientAddress
+	}
+	if req.ClientID != "" {
+		waiting, err := s.store.FindWaitingByClient(ctx, req.ClientID)
+		if err != nil {
+			return models.LobbyTicket{}, "", err
+		}
+		for _, ticket := range waiting {
+			if _, err := s.store.Transition(ctx, ticket.ID, models.LobbyStatusWaiting, models.LobbyStatusCancelled); err != nil {
+				return models.LobbyTicket{}, "", err
+			}
+		}
+	}
+
+	token, tokenHash, err := s.tokenService.GenerateToken()
+	if err != nil {
+		return models.LobbyTicket{}, "", err
+	}
+
+	now := time.Now().UTC()
+	ticket, err := s.store.Create(ctx, models.LobbyTicket{
+		PlayerName: req.PlayerName,
+		Group:      normalizeLobbyFilter(req.Group),
+		AgeBand:    normalizeLobbyFilter(req.AgeBand),
+		ClientID:   req.ClientID,
+		TokenHash:  tokenHash,
+		Status:     models.LobbyStatusWaiting,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(s.timeout),
+	})
+	if err != nil {
+		return models.LobbyTicket{}, "", err
+	}
+
+	ticket, err = s.Refresh(ctx, ticket)
+	if err != nil {
+		return models.LobbyTicket{}, "", err
+	}
+
+	return ticket, token, nil
+}
+
+// GetTicket retrieves a lobby ticket by ID
+func (s *LobbyService) GetTicket(ctx context.Context, id string) (models.LobbyTicket, error) {
+	return s.store.GetByID(ctx, id)
+}
+
+// Refresh expires a timed out ticket or tries to pair a waiting one, and returns its current state
+func (s *LobbyService) Refresh(ctx context.Context, ticket models.LobbyTicket) (models.LobbyTicket, error) {
+	if ticket.Status != models.LobbyStatusWaiting {
+		return ticket, nil
+	}
+
+	now := time.Now()
+	if !ticket.ExpiresAt.After(now) {
+		if _, err := s.store.Transition(ctx, ticket.ID, models.LobbyStatusWaiting, models.LobbyStatusExpired); err != nil {
+			return ticket, err
+		}
+		return s.store.GetByID(ctx, ticket.ID.Hex())
+	}
+
+	if err := s.tryMatch(ctx, ticket, now); err != nil {
+		return ticket, err
+	}
+
+	return s.store.GetByID(ctx, ticket.ID.Hex())
+}
+
+// Cancel removes a waiting ticket from the queue
+func (s *LobbyService) Cancel(ctx context.Context, ticket models.LobbyTicket) error {
+	cancelled, err := s.store.Transition(ctx, ticket.ID, models.LobbyStatusWaiting, models.LobbyStatusCancelled)
+	if err != nil {
+		return err
+	}
+	if !cancelled {
+		return ErrLobbyTicketFinished
+	}
+	return nil
+}
+
+// tryMatch locks the ticket, claims the longest waiting partner and creates their session.
+// Locking the ticket first keeps two matchers from pairing it into two sessions at once.
+func (s *LobbyService) tryMatch(ctx context.Context, ticket models.LobbyTicket, now time.Time) error {
+	locked, err := s.store.Transition(ctx, ticket.ID, models.LobbyStatusWaiting, models.LobbyStatusPairing)
+	if err != nil || !locked {
+		return err
+	}
+
+	partner, err := s.store.ClaimPartner(ctx, ticket, now)
+	if err != nil || partner == nil {
+		s.release(ctx, ticket)
+		return err
+	}
+
+	// The partner has waited longer, so they become player 1
+	session, err := s.createSession(ctx, *partner, ticket)
+	if err != nil {
+		s.release(ctx, *partner)
+		s.release(ctx, ticket)
+		return err
+	}
+
+	if err := s.store.SetMatch(ctx, partner.ID, session.ID, session.Player1ID, 1); err != nil {
+		s.unmatch(ctx, session, *partner, ticket)
+		return err
+	}
+	if err := s.store.SetMatch(ctx, ticket.ID, session.ID, *session.Player2ID, 2); err != nil {
+		s.unmatch(ctx, session, *partner, ticket)
+		return err
+	}
+	return nil
+}
+
+// createSession creates both players and a game session they can play straight away
+func (s *LobbyService) createSession(ctx context.Context, first, second models.LobbyTicket) (models.GameSession, error) {
+	pack, err := s.packService.Pin(ctx, "")
+	if err != nil {
+		return models.GameSession{}, err
+	}
+
+	shuffleSeed, err := newShuffleSeed()
+	if err != nil {
+		return models.GameSession{}, err
+	}
+
+	player1, err := s.playerRepo.Create(ctx, models.Player{Name: first.PlayerName})
+	if err != nil {
+		return models.GameSession{}, fmt.Errorf("failed to create player 1: %v", err)
+	}
+
+	player2, err := s.playerRepo.Create(ctx, models.Player{Name: second.PlayerName})
+	if err != nil {
+		s.deletePlayers(ctx, player1.ID)
+		return models.GameSession{}, fmt.Errorf("failed to create player 2: %v", err)
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(s.sessionTTL)
+	session := models.GameSession{
+		Mode:             models.GameModeClassic,
+		Player1ID:        player1.ID,
+		Player2ID:        &player2.ID,
+		Player1Answers:   []models.PlayerAnswer{},
+		Player2Name:      &second.PlayerName,
+		Player1TokenHash: first.TokenHash,
+		Player2TokenHash: second.TokenHash,
+		CreatedAt:        &now,
+		ExpiresAt:        &expiresAt,
+		PackID:           pack.ID,
+		PackVersion:      pack.PublishedVersion,
+		Shuffle:          models.ShuffleSections,
+		ShuffleSeed:      shuffleSeed,
+	}
+
+	createdSession, err := s.sessionRepo.Create(ctx, session)
+	if err != nil {
+		s.deletePlayers(ctx, player1.ID, player2.ID)
+		return models.GameSession{}, err
+	}
+	return createdSession, nil
+}
+
+// release puts a locked or claimed ticket back in the queue
+func (s *LobbyService) release(ctx context.Context, ticket models.LobbyTicket) {
+	if _, err := s.store.Transition(ctx, ticket.ID, models.LobbyStatusPairing, models.LobbyStatusWaiting); err != nil {
+		log.Printf("Failed to release lobby ticket %s: %v", ticket.ID.Hex(), err)
+	}
+}
+
+// unmatch removes a session whose match could not be recorded and puts both tickets back in the
+// queue, so neither player is left waiting on a pairing that never finishes
+func (s *LobbyService) unmatch(ctx context.Context, session models.GameSession, tickets ...models.LobbyTicket) {
+	if err := s.sessionRepo.Delete(ctx, session.ID.Hex()); err != nil {
+		log.Printf("Failed to remove unmatched lobby session %s: %v", session.ID.Hex(), err)
+	}
+	s.deletePlayers(ctx, session.Player1ID, *session.Player2ID)
+	for _, ticket := range tickets {
+		if err := s.store.ResetMatch(ctx, ticket.ID); err != nil {
+			log.Printf("Failed to release lobby ticket %s: %v", ticket.ID.Hex(), err)
+		}
+	}
+}
+
+// deletePlayers removes players created for a session that was never handed out
+func (s *LobbyService) deletePlayers(ctx context.Context, playerIDs ...primitive.ObjectID) {
+	for _, playerID := range playerIDs {
+		if err := s.playerRepo.Delete(ctx, playerID.Hex()); err != nil {
+			log.Printf("Failed to remove lobby player %s: %v", playerID.Hex(), err)
+		}
+	}
+}
+
+// normalizeLobbyFilter makes group and age band filters case and whitespace insensitive
+func normalizeLobbyFilter(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"get-to-know-game-go/i18n"
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/repositories"
+)
+
+var (
+	// ErrUnsupportedLanguage is returned for a translation into a language that isn't supported
+	ErrUnsupportedLanguage = errors.New("unsupported translation language")
+	// ErrTranslationTextRequired is returned for a question translation without text
+	ErrTranslationTextRequired = errors.New("translation has no question text")
+)
+
+// LocalizationService serves questions and sections in the language of a request
+type LocalizationService struct {
+	sectionRepo repositories.SectionRepository
+}
+
+// NewLocalizationService creates a new localization service
+func NewLocalizationService(sectionRepo repositories.SectionRepository) *LocalizationService {
+	return &LocalizationService{
+		sectionRepo: sectionRepo,
+	}
+}
+
+// Localizer translates questions and sections into one language, falling back to the stored
+// text where there is no translation. Question IDs are never changed, so players answering in
+// different languages still have their answers matched.
+type Localizer struct {
+	language string
+	sections map[string]models.Section
+}
+
+// Localizer returns a localizer for language
+func (s *LocalizationService) Localizer(ctx context.Context, language string) (*Localizer, error) {
+	sections, err := s.sectionRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sectionsByKey := make(map[string]models.Section, len(sections))
+	for _, section := range sections {
+		sectionsByKey[section.Key] = section
+	}
+	return &Localizer{language: language, sections: sectionsByKey}, nil
+}
+
+// Questions returns questions with their text and section name translated
+func (l *Localizer) Questions(questions []models.Question) []models.Question {
+	localized := make([]models.Question, len(questions))
+	for i, question := range questions {
+		localized[i] = l.Question(question)
+	}
+	return localized
+}
+
+// Question returns question with its text and section name translated
+func (l *Localizer) Question(question models.Question) models.Question {
+	question = question.Localized(l.language)
+	question.Section = l.SectionName(question.Section)
+	return question
+}
+
+// Sections returns sections with their names and descriptions translated
+func (l *Localizer) Sections(sections []models.Section) []models.Section {
+	localized := make([]models.Section, len(sections))
+	for i, section := range sections {
+		localized[i] = section.Localized(l.language)
+	}
+	return localized
+}
+
+// SectionName translates the name of a section; unknown sections keep their name
+func (l *Localizer) SectionName(name string) string {
+	section, exists := l.sections[models.SectionKey(name)]
+	if !exists {
+		return name
+	}
+	return section.Localized(l.language).Name
+}
+
+// validateTranslationLanguages rejects translations into unsupported languages
+func validateTranslationLanguages[T any](translations map[string]T) error {
+	for language := range translations {
+		if !i18n.IsSupported(language) {
+			return fmt.Errorf("%w %q", ErrUnsupportedLanguage, language)
+		}
+	}
+	return nil
+}
+
+// ValidateQuestionTranslations rejects question translations into unsupported languages or without text
+func ValidateQuestionTranslations(translations map[string]models.QuestionTranslation) error {
+	if err := validateTranslationLanguages(translations); err != nil {
+		return err
+	}
+	for language, translation := range translations {
+		if translation.QuestionText == "" {
+			return fmt.Errorf("%w: %q", ErrTranslationTextRequired, language)
+		}
+	}
+	return nil
+}
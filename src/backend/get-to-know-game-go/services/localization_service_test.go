@@ -0,0 +1,68 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"get-to-know-game-go/i18n"
+	"get-to-know-game-go/models"
+)
+
+func TestLocalizerFallsBackToStoredText(t *testing.T) {
+	food := models.Section{
+		Key:          "food",
+		Name:         "Food",
+		Translations: map[string]models.SectionTranslation{i18n.Romanian: {Name: "Mâncare"}},
+	}
+	question := models.Question{
+		Section:      "Food",
+		QuestionText: "Sushi",
+		Translations: map[string]models.QuestionTranslation{i18n.Romanian: {QuestionText: "Sushi, te rog"}},
+	}
+	travel := models.Question{Section: "Travel", QuestionText: "Camping"}
+
+	tests := []struct {
+		name        string
+		language    string
+		question    models.Question
+		wantText    string
+		wantSection string
+	}{
+		{name: "translated", language: i18n.Romanian, question: question, wantText: "Sushi, te rog", wantSection: "Mâncare"},
+		{name: "english", language: i18n.English, question: question, wantText: "Sushi", wantSection: "Food"},
+		{name: "no translation", language: i18n.Spanish, question: question, wantText: "Sushi", wantSection: "Food"},
+		{name: "unknown section", language: i18n.Romanian, question: travel, wantText: "Camping", wantSection: "Travel"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			localizer := &Localizer{language: tt.language, sections: map[string]models.Section{food.Key: food}}
+			got := localizer.Question(tt.question)
+			if got.QuestionText != tt.wantText || got.Section != tt.wantSection {
+				t.Errorf("got %q in %q, want %q in %q", got.QuestionText, got.Section, tt.wantText, tt.wantSection)
+			}
+		})
+	}
+}
+
+func TestValidateQuestionTranslations(t *testing.T) {
+	tests := []struct {
+		name         string
+		translations map[string]models.QuestionTranslation
+		want         error
+	}{
+		{name: "none"},
+		{name: "supported", translations: map[string]models.QuestionTranslation{i18n.Spanish: {QuestionText: "Acampar"}}},
+		{name: "unsupported", translations: map[string]models.QuestionTranslation{"fr": {QuestionText: "Camper"}}, want: ErrUnsupportedLanguage},
+		{name: "region tag", translations: map[string]models.QuestionTranslation{"es-MX": {QuestionText: "Acampar"}}, want: ErrUnsupportedLanguage},
+		{name: "empty text", translations: map[string]models.QuestionTranslation{i18n.Romanian: {}}, want: ErrTranslationTextRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateQuestionTranslations(tt.translations); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,376 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	// ErrPackNotFound is returned when no pack has the given ID or key
+	ErrPackNotFound = errors.New("question pack not found")
+	// ErrPackExists is returned when another pack already has the key a name derives to
+	ErrPackExists = errors.New("a question pack with this name already exists")
+	// ErrPackNotPublished is returned when a session is created from a pack without a published version
+	ErrPackNotPublished = errors.New("question pack has not been published")
+	// ErrPackVersionNotFound is returned for a version the pack never published
+	ErrPackVersionNotFound = errors.New("pack version not found")
+	// ErrPackEmpty is returned when publishing a draft without questions
+	ErrPackEmpty = errors.New("question pack draft has no questions")
+	// ErrPublishConflict is returned when another version of the pack was published concurrently
+	ErrPublishConflict = errors.New("another version of the pack was published at the same time")
+	// ErrPackNameRequired is returned when creating or renaming a pack without a name
+	ErrPackNameRequired = errors.New("pack name is required")
+)
+
+// defaultPackName is the name of the pack created for the existing question bank
+const defaultPackName = "Default"
+
+// PackService manages question packs, their drafts and their published versions
+type PackService struct {
+	packRepo       repositories.QuestionPackRepository
+	versionRepo    repositories.PackVersionRepository
+	questionRepo   repositories.QuestionRepository
+	sectionService *SectionService
+}
+
+// NewPackService creates a new pack service
+func NewPackService(packRepo repositories.QuestionPackRepository, versionRepo repositories.PackVersionRepository, questionRepo repositories.QuestionRepository, sectionService *SectionService) *PackService {
+	return &PackService{
+		packRepo:       packRepo,
+		versionRepo:    versionRepo,
+		questionRepo:   questionRepo,
+		sectionService: sectionService,
+	}
+}
+
+// ListPacks returns every pack by name
+func (s *PackService) ListPacks(ctx context.Context) ([]models.QuestionPack, error) {
+	return s.packRepo.GetAllOrdered(ctx)
+}
+
+// GetPack retrieves a pack by ID or key; an empty reference means the default pack
+func (s *PackService) GetPack(ctx context.Context, ref string) (models.QuestionPack, error) {
+	var pack models.QuestionPack
+	var err error
+	switch {
+	case ref == "":
+		pack, err = s.packRepo.GetByKey(ctx, models.DefaultPackKey)
+	case primitive.IsValidObjectID(ref):
+		pack, err = s.packRepo.GetByID(ctx, ref)
+	default:
+		pack, err = s.packRepo.GetByKey(ctx, strings.ToLower(ref))
+	}
+	if err != nil {
+		return models.QuestionPack{}, ErrPackNotFound
+	}
+	return pack, nil
+}
+
+// CreatePack creates an empty, unpublished pack
+func (s *PackService) CreatePack(ctx context.Context, req models.CreatePackRequest) (models.QuestionPack, error) {
+	name := strings.TrimSpace(req.Name)
+	if models.PackKey(name) == "" {
+		return models.QuestionPack{}, ErrPackNameRequired
+	}
+
+	pack, err := s.packRepo.Create(ctx, models.QuestionPack{
+		Key:         models.PackKey(name),
+		Name:        name,
+		Description: req.Description,
+		CreatedAt:   time.Now().UTC(),
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return models.QuestionPack{}, ErrPackExists
+	}
+	return pack, err
+}
+
+// UpdatePack renames a pack or changes its description; its key stays the same
+func (s *PackService) UpdatePack(ctx context.Context, ref string, req models.UpdatePackRequest) (models.QuestionPack, error) {
+	pack, err := s.GetPack(ctx, ref)
+	if err != nil {
+		return models.QuestionPack{}, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return models.QuestionPack{}, ErrPackNameRequired
+	}
+	pack.Name = name
+	pack.Description = req.Description
+
+	if err := s.packRepo.Update(ctx, pack.ID.Hex(), pack); err != nil {
+		return models.QuestionPack{}, err
+	}
+	return pack, nil
+}
+
+// DraftQuestions returns the pack's draft in section order, then question order. The default
+// pack's draft includes questions created without a pack.
+func (s *PackService) DraftQuestions(ctx context.Context, pack models.QuestionPack, includeInactive bool) ([]models.Question, error) {
+	questions, err := s.questionRepo.GetByPack(ctx, pack.ID, pack.Key == models.DefaultPackKey)
+	if err != nil {
+		return nil, err
+	}
+	return s.sectionService.OrderQuestions(ctx, questions, includeInactive)
+}
+
+// DefaultDraftQuestions returns the default pack's draft as it would be published.
+// Sessions created before packs existed keep playing it.
+func (s *PackService) DefaultDraftQuestions(ctx context.Context) ([]models.Question, error) {
+	pack, err := s.GetPack(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return s.DraftQuestions(ctx, pack, false)
+}
+
+// Preview returns the questions publishing the pack would serve, with what changed since the
+// published version
+func (s *PackService) Preview(ctx context.Context, ref string) (models.PackPreview, error) {
+	pack, err := s.GetPack(ctx, ref)
+	if err != nil {
+		return models.PackPreview{}, err
+	}
+
+	draft, err := s.DraftQuestions(ctx, pack, false)
+	if err != nil {
+		return models.PackPreview{}, err
+	}
+
+	var published []models.Question
+	if pack.PublishedVersion > 0 {
+		published, err = s.VersionQuestions(ctx, pack.ID, pack.PublishedVersion)
+		if err != nil {
+			return models.PackPreview{}, err
+		}
+	}
+
+	preview := models.PackPreview{
+		Pack:             pack,
+		PublishedVersion: pack.PublishedVersion,
+		Questions:        draft,
+		Added:            []models.PackChange{},
+		Removed:          []models.PackChange{},
+		Changed:          []models.PackChange{},
+	}
+
+	publishedByID := make(map[primitive.ObjectID]models.Question, len(published))
+	for _, question := range published {
+		publishedByID[question.ID] = question
+	}
+	for _, question := range draft {
+		previous, exists := publishedByID[question.ID]
+		switch {
+		case !exists:
+			preview.Added = append(preview.Added, packChange(question))
+		case packQuestionChanged(previous, question):
+			preview.Changed = append(preview.Changed, packChange(question))
+		}
+		delete(publishedByID, question.ID)
+	}
+	for _, question := range published {
+		if _, removed := publishedByID[question.ID]; removed {
+			preview.Removed = append(preview.Removed, packChange(question))
+		}
+	}
+
+	return preview, nil
+}
+
+// Publish snapshots the pack's draft as a new version and serves it to new sessions. Readers
+// only find versions through the pack, so the switch happens in a single update.
+func (s *PackService) Publish(ctx context.Context, ref, note string) (models.PackVersion, error) {
+	pack, err := s.GetPack(ctx, ref)
+	if err != nil {
+		return models.PackVersion{}, err
+	}
+
+	questions, err := s.DraftQuestions(ctx, pack, false)
+	if err != nil {
+		return models.PackVersion{}, err
+	}
+	return s.publish(ctx, pack, note, questions)
+}
+
+// PublishWith publishes the pack's published version with questions added or, when they are
+// already in it, replaced. The rest of the draft stays unpublished.
+func (s *PackService) PublishWith(ctx context.Context, ref, note string, questions []models.Question) (models.PackVersion, error) {
+	pack, err := s.GetPack(ctx, ref)
+	if err != nil {
+		return models.PackVersion{}, err
+	}
+
+	var published []models.Question
+	if pack.PublishedVersion > 0 {
+		published, err = s.VersionQuestions(ctx, pack.ID, pack.PublishedVersion)
+		if err != nil {
+			return models.PackVersion{}, err
+		}
+	}
+
+	replaced := make(map[primitive.ObjectID]bool, len(questions))
+	for _, question := range questions {
+		replaced[question.ID] = true
+	}
+	merged := append([]models.Question{}, questions...)
+	for _, question := range published {
+		if !replaced[question.ID] {
+			merged = append(merged, question)
+		}
+	}
+
+	merged, err = s.sectionService.OrderQuestions(ctx, merged, false)
+	if err != nil {
+		return models.PackVersion{}, err
+	}
+	return s.publish(ctx, pack, note, merged)
+}
+
+// publish stores questions as the pack's next version and serves it to new sessions
+func (s *PackService) publish(ctx context.Context, pack models.QuestionPack, note string, questions []models.Question) (models.PackVersion, error) {
+	if len(questions) == 0 {
+		return models.PackVersion{}, ErrPackEmpty
+	}
+
+	version, err := s.versionRepo.Create(ctx, models.PackVersion{
+		PackID:        pack.ID,
+		Version:       pack.LatestVersion + 1,
+		Note:          strings.TrimSpace(note),
+		PublishedAt:   time.Now().UTC(),
+		Questions:     questions,
+		QuestionCount: len(questions),
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return models.PackVersion{}, ErrPublishConflict
+	}
+	if err != nil {
+		return models.PackVersion{}, err
+	}
+
+	published, err := s.packRepo.Publish(ctx, pack.ID, pack.LatestVersion, version.Version)
+	if err == nil && !published {
+		err = ErrPublishConflict
+	}
+	if err != nil {
+		// Nothing refers to the unpublished snapshot yet, so it can go
+		_ = s.versionRepo.Delete(ctx, version.ID.Hex())
+		return models.PackVersion{}, err
+	}
+
+	return version, nil
+}
+
+// Rollback serves an earlier published version of the pack to new sessions again.
+// Sessions keep the version they were created with.
+func (s *PackService) Rollback(ctx context.Context, ref string, version int) (models.QuestionPack, error) {
+	pack, err := s.GetPack(ctx, ref)
+	if err != nil {
+		return models.QuestionPack{}, err
+	}
+
+	if _, err := s.versionRepo.GetVersion(ctx, pack.ID, version); err != nil {
+		return models.QuestionPack{}, ErrPackVersionNotFound
+	}
+	if err := s.packRepo.SetPublishedVersion(ctx, pack.ID, version); err != nil {
+		return models.QuestionPack{}, err
+	}
+
+	pack.PublishedVersion = version
+	return pack, nil
+}
+
+// Versions lists the published versions of a pack, newest first, without their questions
+func (s *PackService) Versions(ctx context.Context, ref string) ([]models.PackVersion, error) {
+	pack, err := s.GetPack(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return s.versionRepo.ListByPack(ctx, pack.ID)
+}
+
+// Version retrieves one published version of a pack with its questions
+func (s *PackService) Version(ctx context.Context, ref string, version int) (models.PackVersion, error) {
+	pack, err := s.GetPack(ctx, ref)
+	if err != nil {
+		return models.PackVersion{}, err
+	}
+
+	packVersion, err := s.versionRepo.GetVersion(ctx, pack.ID, version)
+	if err != nil {
+		return models.PackVersion{}, ErrPackVersionNotFound
+	}
+	return packVersion, nil
+}
+
+// Pin returns the pack a new session or room plays, which must have a published version
+func (s *PackService) Pin(ctx context.Context, ref string) (models.QuestionPack, error) {
+	pack, err := s.GetPack(ctx, ref)
+	if err != nil {
+		return models.QuestionPack{}, err
+	}
+	if pack.PublishedVersion == 0 {
+		return models.QuestionPack{}, ErrPackNotPublished
+	}
+	return pack, nil
+}
+
+// VersionQuestions returns the questions of a published pack version
+func (s *PackService) VersionQuestions(ctx context.Context, packID primitive.ObjectID, version int) ([]models.Question, error) {
+	packVersion, err := s.versionRepo.GetVersion(ctx, packID, version)
+	if err != nil {
+		return nil, err
+	}
+	return packVersion.Questions, nil
+}
+
+// EnsureDefaultPack creates the default pack for the existing question bank and publishes it
+// the first time, so games keep working without an admin publishing first
+func (s *PackService) EnsureDefaultPack(ctx context.Context) error {
+	pack, err := s.GetPack(ctx, "")
+	if err == ErrPackNotFound {
+		pack, err = s.packRepo.Create(ctx, models.QuestionPack{
+			Key:       models.DefaultPackKey,
+			Name:      defaultPackName,
+			CreatedAt: time.Now().UTC(),
+		})
+		if mongo.IsDuplicateKeyError(err) {
+			// Created concurrently by another instance
+			pack, err = s.GetPack(ctx, "")
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if pack.PublishedVersion > 0 {
+		return nil
+	}
+	_, err = s.Publish(ctx, pack.ID.Hex(), "Initial version")
+	if err == ErrPackEmpty || err == ErrPublishConflict {
+		return nil
+	}
+	return err
+}
+
+// packChange identifies a question in a pack preview
+func packChange(question models.Question) models.PackChange {
+	return models.PackChange{
+		QuestionID:   question.ID.Hex(),
+		Section:      question.Section,
+		QuestionText: question.QuestionText,
+	}
+}
+
+// packQuestionChanged reports whether a draft question differs from its published version
+func packQuestionChanged(published, draft models.Question) bool {
+	return !questionUnchanged(published, draft)
+}
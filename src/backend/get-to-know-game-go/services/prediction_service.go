@@ -0,0 +1,90 @@
+package services
+
+import (
+	"get-to-know-game-go/models"
+)
+
+// PredictionService scores how well players predicted each other's answers
+type PredictionService struct{}
+
+// NewPredictionService creates a new prediction service
+func NewPredictionService() *PredictionService {
+	return &PredictionService{}
+}
+
+// CalculateAccuracy compares the predictions in predictorAnswers with the partner's actual responses
+func (s *PredictionService) CalculateAccuracy(predictorID string, predictorAnswers, partnerAnswers []models.PlayerAnswer) models.PredictionAccuracy {
+	partnerAnswerMap := make(map[string]string)
+	for _, answer := range partnerAnswers {
+		partnerAnswerMap[answer.QuestionID.Hex()] = answer.Response
+	}
+
+	accuracy := models.PredictionAccuracy{
+		PlayerID:  predictorID,
+		Breakdown: []models.PredictionOutcome{},
+	}
+
+	for _, answer := range predictorAnswers {
+		actual, exists := partnerAnswerMap[answer.QuestionID.Hex()]
+		if !exists || answer.Prediction == "" {
+			continue
+		}
+
+		correct := answer.Prediction == actual
+		if correct {
+			accuracy.Correct++
+		}
+		accuracy.Total++
+
+		accuracy.Breakdown = append(accuracy.Breakdown, models.PredictionOutcome{
+			QuestionID: answer.QuestionID.Hex(),
+			Predicted:  answer.Prediction,
+			Actual:     actual,
+			Correct:    correct,
+		})
+	}
+
+	if accuracy.Total > 0 {
+		accuracy.Score = int(float64(accuracy.Correct)/float64(accuracy.Total)*100 + 0.5)
+	}
+
+	return accuracy
+}
+
+// SessionAccuracy calculates both players' accuracy for a completed session as shown to the
+// players, where nothing reveals whether the partner gave a negative response such as "Nay!"
+func (s *PredictionService) SessionAccuracy(session models.GameSession) (models.PredictionAccuracy, models.PredictionAccuracy) {
+	player1Accuracy := s.CalculateAccuracy(session.Player1ID.Hex(), session.Player1Answers, *session.Player2Answers)
+	player2Accuracy := s.CalculateAccuracy(session.Player2ID.Hex(), *session.Player2Answers, session.Player1Answers)
+	return redactNegativeAnswers(player1Accuracy), redactNegativeAnswers(player2Accuracy)
+}
+
+// redactNegativeAnswers keeps only what a player can learn without finding out the partner's
+// negative responses. A correct negative prediction would reveal one, so negative predictions are
+// left out of the breakdown and the score. Of the other predictions only whether they were correct
+// is shown: the actual response of a wrong guess could be negative, so it is never shown.
+func redactNegativeAnswers(accuracy models.PredictionAccuracy) models.PredictionAccuracy {
+	redacted := models.PredictionAccuracy{
+		PlayerID:  accuracy.PlayerID,
+		Breakdown: []models.PredictionOutcome{},
+	}
+
+	for _, outcome := range accuracy.Breakdown {
+		if models.IsNegativeResponse(outcome.Predicted) {
+			continue
+		}
+		if !outcome.Correct {
+			outcome.Actual = ""
+		}
+		if outcome.Correct {
+			redacted.Correct++
+		}
+		redacted.Total++
+		redacted.Breakdown = append(redacted.Breakdown, outcome)
+	}
+
+	if redacted.Total > 0 {
+		redacted.Score = int(float64(redacted.Correct)/float64(redacted.Total)*100 + 0.5)
+	}
+	return redacted
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"get-to-know-game-go/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// predictionSession builds a completed prediction session where player 1 predicts player 2's responses
+func predictionSession(questionIDs []primitive.ObjectID, predictions, partnerResponses []string) models.GameSession {
+	player2ID := primitive.NewObjectID()
+	player1Answers := make([]models.PlayerAnswer, len(questionIDs))
+	player2Answers := make([]models.PlayerAnswer, len(questionIDs))
+	for i, id := range questionIDs {
+		player1Answers[i] = models.PlayerAnswer{QuestionID: id, Response: models.Yay, Prediction: predictions[i]}
+		player2Answers[i] = models.PlayerAnswer{QuestionID: id, Response: partnerResponses[i], Prediction: models.Yay}
+	}
+	return models.GameSession{
+		ID:             primitive.NewObjectID(),
+		Mode:           models.GameModePrediction,
+		Player1ID:      primitive.NewObjectID(),
+		Player2ID:      &player2ID,
+		Player1Answers: player1Answers,
+		Player2Answers: &player2Answers,
+	}
+}
+
+func TestSessionAccuracyHidesNegativePartnerAnswers(t *testing.T) {
+	tests := []struct {
+		name        string
+		predictions []string
+		// negative and other differ only where the partner answered negatively in one and not in the other
+		negative []string
+		other    []string
+	}{
+		{
+			name:        "nay predicted",
+			predictions: []string{models.Nay, models.Yay},
+			negative:    []string{models.Nay, models.Yay},
+			other:       []string{models.DontCare, models.Yay},
+		},
+		{
+			name:        "yay predicted",
+			predictions: []string{models.Yay, models.DontCare},
+			negative:    []string{models.Nay, models.DontCare},
+			other:       []string{models.DontCare, models.DontCare},
+		},
+		{
+			name:        "dont care predicted",
+			predictions: []string{models.DontCare, models.Yay},
+			negative:    []string{models.Nay, models.Nay},
+			other:       []string{models.Yay, models.DontCare},
+		},
+		{
+			name:        "likert",
+			predictions: []string{"1", "4", "2"},
+			negative:    []string{"1", "2", "2"},
+			other:       []string{"5", "3", "4"},
+		},
+		{
+			name:        "yes no",
+			predictions: []string{models.No, models.Yes},
+			negative:    []string{models.No, models.No},
+			other:       []string{models.Yes, models.No},
+		},
+	}
+
+	service := NewPredictionService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			questionIDs := make([]primitive.ObjectID, len(tt.predictions))
+			for i := range questionIDs {
+				questionIDs[i] = primitive.NewObjectID()
+			}
+
+			negativeSession := predictionSession(questionIDs, tt.predictions, tt.negative)
+			otherSession := predictionSession(questionIDs, tt.predictions, tt.other)
+			otherSession.Player1ID, otherSession.Player2ID = negativeSession.Player1ID, negativeSession.Player2ID
+
+			negativeView, _ := service.SessionAccuracy(negativeSession)
+			otherView, _ := service.SessionAccuracy(otherSession)
+			if !reflect.DeepEqual(negativeView, otherView) {
+				t.Errorf("player 1's view reveals the partner's negative answers:\n%+v\n%+v", negativeView, otherView)
+			}
+
+			for _, outcome := range negativeView.Breakdown {
+				if models.IsNegativeResponse(outcome.Actual) || models.IsNegativeResponse(outcome.Predicted) {
+					t.Errorf("breakdown row %+v mentions a negative response", outcome)
+				}
+			}
+		})
+	}
+}
+
+func TestSessionAccuracyScoresRevealableGuesses(t *testing.T) {
+	questionIDs := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()}
+	session := predictionSession(questionIDs,
+		[]string{models.Yay, models.DontCare, models.Nay, models.Yay},
+		[]string{models.Yay, models.Yay, models.Nay, models.DontCare},
+	)
+
+	accuracy, _ := NewPredictionService().SessionAccuracy(session)
+	if accuracy.Total != 3 || accuracy.Correct != 1 || accuracy.Score != 33 {
+		t.Fatalf("got %d of %d correct, score %d; want 1 of 3, score 33", accuracy.Correct, accuracy.Total, accuracy.Score)
+	}
+
+	want := []models.PredictionOutcome{
+		{QuestionID: questionIDs[0].Hex(), Predicted: models.Yay, Actual: models.Yay, Correct: true},
+		{QuestionID: questionIDs[1].Hex(), Predicted: models.DontCare},
+		{QuestionID: questionIDs[3].Hex(), Predicted: models.Yay},
+	}
+	if !reflect.DeepEqual(accuracy.Breakdown, want) {
+		t.Errorf("breakdown = %+v, want %+v", accuracy.Breakdown, want)
+	}
+}
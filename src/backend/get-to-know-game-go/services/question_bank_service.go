@@ -0,0 +1,521 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"get-to-know-game-go/i18n"
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrInvalidQuestionBank is returned when a question bank file can't be read at all
+	ErrInvalidQuestionBank = errors.New("question bank file could not be parsed")
+	// ErrQuestionBankRejected is returned when an import is not applied because some rows are invalid
+	ErrQuestionBankRejected = errors.New("question bank has invalid rows")
+)
+
+// questionBankColumns are the CSV columns of a question bank, in export order. A translation
+// column per supported language follows them.
+var questionBankColumns = []string{"key", "section", "questionText", "responseType", "weight", "order", "tags", "rating", "pack"}
+
+// translationColumn returns the CSV column holding a question's text in language, such as questionText.ro
+func translationColumn(language string) string {
+	return "questionText." + language
+}
+
+// translationLanguages are the languages questions are translated into, in export order
+func translationLanguages() []string {
+	languages := []string{}
+	for _, language := range i18n.SupportedLanguages() {
+		if language != i18n.English {
+			languages = append(languages, language)
+		}
+	}
+	return languages
+}
+
+// csvTagSeparator separates the tags of a question within the tags column of a CSV file
+const csvTagSeparator = ";"
+
+// QuestionBankService imports and exports the question bank as CSV, JSON or YAML
+type QuestionBankService struct {
+	questionRepo   repositories.QuestionRepository
+	sectionService *SectionService
+	packService    *PackService
+}
+
+// NewQuestionBankService creates a new question bank service
+func NewQuestionBankService(questionRepo repositories.QuestionRepository, sectionService *SectionService, packService *PackService) *QuestionBankService {
+	return &QuestionBankService{
+		questionRepo:   questionRepo,
+		sectionService: sectionService,
+		packService:    packService,
+	}
+}
+
+// questionBankRow is a parsed row of an imported file; err is set when the row could not be read
+type questionBankRow struct {
+	record models.QuestionRecord
+	err    error
+}
+
+// Export writes the whole question bank, including inactive sections, in section and question order.
+// Every question names the pack it belongs to.
+func (s *QuestionBankService) Export(ctx context.Context, format string) ([]byte, error) {
+	questions, err := s.sectionService.Questions(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	packs, err := s.packService.ListPacks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	packKeys := make(map[primitive.ObjectID]string, len(packs))
+	for _, pack := range packs {
+		packKeys[pack.ID] = pack.Key
+	}
+
+	records := make([]models.QuestionRecord, 0, len(questions))
+	for _, question := range questions {
+		records = append(records, models.QuestionRecord{
+			Key:          question.Key,
+			Section:      question.Section,
+			QuestionText: question.QuestionText,
+			ResponseType: question.ResponseType,
+			Weight:       question.Weight,
+			Order:        question.Order,
+			Tags:         question.Tags,
+			Rating:       question.Rating,
+			Pack:         packKey(question, packKeys),
+			Translations: question.Translations,
+		})
+	}
+
+	switch format {
+	case models.QuestionBankCSV:
+		return encodeQuestionBankCSV(records)
+	case models.QuestionBankJSON:
+		return json.MarshalIndent(records, "", "  ")
+	case models.QuestionBankYAML:
+		return yaml.Marshal(records)
+	}
+	return nil, fmt.Errorf("unsupported question bank format %q", format)
+}
+
+// Import upserts the questions of a question bank file. Rows are matched to existing questions
+// by key, or by section and question text when they have no key or the key is unknown. Rows that
+// would change nothing, including repeats within the file, are reported as duplicates. Omitted
+// response types, weights, tags, ratings, packs and translations leave the current values unchanged; new
+// questions without a pack join the default pack.
+//
+// Unless options.SkipInvalid is set, a file with any invalid row is rejected as a whole with
+// ErrQuestionBankRejected; the returned report lists the invalid rows either way.
+func (s *QuestionBankService) Import(ctx context.Context, data []byte, options models.ImportOptions) (models.ImportReport, error) {
+	report := models.ImportReport{
+		DryRun:     options.DryRun,
+		Created:    []models.ImportRow{},
+		Updated:    []models.ImportRow{},
+		Duplicates: []models.ImportRow{},
+		Errors:     []models.ImportError{},
+	}
+
+	rows, err := parseQuestionBank(data, options.Format)
+	if err != nil {
+		return report, err
+	}
+
+	existing, err := s.questionRepo.GetAll(ctx)
+	if err != nil {
+		return report, err
+	}
+	packs, err := s.packService.ListPacks(ctx)
+	if err != nil {
+		return report, err
+	}
+	packIDs := make(map[string]primitive.ObjectID, len(packs))
+	for _, pack := range packs {
+		packIDs[pack.Key] = pack.ID
+	}
+	// Questions created before packs existed have no pack ID and belong to the default pack
+	packOf := func(question models.Question) primitive.ObjectID {
+		if question.PackID.IsZero() {
+			return packIDs[models.DefaultPackKey]
+		}
+		return question.PackID
+	}
+
+	byKey := make(map[string]models.Question, len(existing))
+	byText := make(map[string]models.Question, len(existing))
+	for _, question := range existing {
+		if question.Key != "" {
+			byKey[question.Key] = question
+		}
+		byText[questionIdentity(question.Section, question.QuestionText)] = question
+	}
+
+	var creates []models.Question
+	var updates []models.Question
+	seen := make(map[string]bool, len(rows))
+	for index, row := range rows {
+		rowNumber := index + 1
+		record := normalizeQuestionRecord(row.record)
+		if row.err == nil {
+			row.err = validateQuestionRecord(record)
+		}
+		packID, knownPack := packIDs[record.Pack]
+		if row.err == nil && record.Pack != "" && !knownPack {
+			row.err = fmt.Errorf("unknown question pack %q", record.Pack)
+		}
+		if row.err != nil {
+			report.Errors = append(report.Errors, models.ImportError{Row: rowNumber, Error: row.err.Error()})
+			continue
+		}
+
+		identity := questionIdentity(record.Section, record.QuestionText)
+		if record.Key != "" {
+			identity = "key:" + record.Key
+		}
+		importRow := models.ImportRow{
+			Row:          rowNumber,
+			Key:          record.Key,
+			Section:      record.Section,
+			QuestionText: record.QuestionText,
+		}
+		if seen[identity] {
+			report.Duplicates = append(report.Duplicates, importRow)
+			continue
+		}
+		seen[identity] = true
+
+		current, exists := byKey[record.Key]
+		if !exists || record.Key == "" {
+			// A question already carrying another key is a different question with the same text
+			current, exists = byText[questionIdentity(record.Section, record.QuestionText)]
+			exists = exists && (current.Key == "" || record.Key == "")
+		}
+		if !exists {
+			if record.Key == "" {
+				record.Key = models.QuestionKey(record.Section, record.QuestionText)
+				importRow.Key = record.Key
+			}
+			created := applyQuestionRecord(models.Question{}, record)
+			created.PackID = packID
+			creates = append(creates, created)
+			report.Created = append(report.Created, importRow)
+			continue
+		}
+
+		updated := applyQuestionRecord(current, record)
+		if updated.Key == "" {
+			updated.Key = models.QuestionKey(record.Section, record.QuestionText)
+		}
+		if record.Pack != "" {
+			updated.PackID = packID
+		}
+		importRow.Key = updated.Key
+		if questionUnchanged(current, updated) && packOf(current) == packOf(updated) {
+			report.Duplicates = append(report.Duplicates, importRow)
+			continue
+		}
+		updates = append(updates, updated)
+		report.Updated = append(report.Updated, importRow)
+	}
+
+	if len(report.Errors) > 0 && !options.SkipInvalid {
+		return report, ErrQuestionBankRejected
+	}
+	if options.DryRun {
+		return report, nil
+	}
+
+	for _, question := range creates {
+		section, err := s.sectionService.ResolveSection(ctx, question.Section)
+		if err != nil {
+			return report, err
+		}
+		question.Section, question.SectionID = section.Name, section.ID
+		if _, err := s.questionRepo.Create(ctx, question); err != nil {
+			return report, err
+		}
+	}
+	for _, question := range updates {
+		section, err := s.sectionService.ResolveSection(ctx, question.Section)
+		if err != nil {
+			return report, err
+		}
+		question.Section, question.SectionID = section.Name, section.ID
+		if err := s.questionRepo.Update(ctx, question.ID.Hex(), question); err != nil {
+			return report, err
+		}
+	}
+
+	report.Applied = true
+	return report, nil
+}
+
+// QuestionBankContentType returns the MIME type of a question bank format
+func QuestionBankContentType(format string) string {
+	switch format {
+	case models.QuestionBankCSV:
+		return "text/csv"
+	case models.QuestionBankYAML:
+		return "application/yaml"
+	}
+	return "application/json"
+}
+
+// parseQuestionBank reads the rows of a question bank file
+func parseQuestionBank(data []byte, format string) ([]questionBankRow, error) {
+	switch format {
+	case models.QuestionBankCSV:
+		return parseQuestionBankCSV(data)
+	case models.QuestionBankJSON:
+		var records []models.QuestionRecord
+		if err := json.Unmarshal(data, &records); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuestionBank, err)
+		}
+		return questionBankRows(records), nil
+	case models.QuestionBankYAML:
+		var records []models.QuestionRecord
+		if err := yaml.Unmarshal(data, &records); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuestionBank, err)
+		}
+		return questionBankRows(records), nil
+	}
+	return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidQuestionBank, format)
+}
+
+func questionBankRows(records []models.QuestionRecord) []questionBankRow {
+	rows := make([]questionBankRow, len(records))
+	for i, record := range records {
+		rows[i] = questionBankRow{record: record}
+	}
+	return rows
+}
+
+// parseQuestionBankCSV reads a CSV file whose first line names the columns. Columns may come
+// in any order; unknown columns are ignored. A malformed number only invalidates its own row.
+func parseQuestionBankCSV(data []byte) ([]questionBankRow, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidQuestionBank, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	if _, exists := columns["questionText"]; !exists {
+		return nil, fmt.Errorf("%w: missing questionText column", ErrInvalidQuestionBank)
+	}
+
+	var rows []questionBankRow
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuestionBank, err)
+		}
+
+		field := func(name string) string {
+			if i, exists := columns[name]; exists && i < len(fields) {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+
+		row := questionBankRow{record: models.QuestionRecord{
+			Key:          field("key"),
+			Section:      field("section"),
+			QuestionText: field("questionText"),
+			ResponseType: field("responseType"),
+			Rating:       field("rating"),
+			Pack:         field("pack"),
+		}}
+		if tags := field("tags"); tags != "" {
+			row.record.Tags = strings.Split(tags, csvTagSeparator)
+		}
+		for name := range columns {
+			language, isTranslation := strings.CutPrefix(name, translationColumn(""))
+			if text := field(name); isTranslation && text != "" {
+				if row.record.Translations == nil {
+					row.record.Translations = make(map[string]models.QuestionTranslation)
+				}
+				row.record.Translations[language] = models.QuestionTranslation{QuestionText: text}
+			}
+		}
+		if weight := field("weight"); weight != "" {
+			value, err := strconv.ParseFloat(weight, 64)
+			if err != nil {
+				row.err = fmt.Errorf("weight %q is not a number", weight)
+			}
+			row.record.Weight = &value
+		}
+		if order := field("order"); order != "" && row.err == nil {
+			value, err := strconv.Atoi(order)
+			if err != nil {
+				row.err = fmt.Errorf("order %q is not a whole number", order)
+			}
+			row.record.Order = value
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// encodeQuestionBankCSV writes records as CSV with a header line
+func encodeQuestionBankCSV(records []models.QuestionRecord) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	languages := translationLanguages()
+	header := append([]string{}, questionBankColumns...)
+	for _, language := range languages {
+		header = append(header, translationColumn(language))
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		weight := ""
+		if record.Weight != nil {
+			weight = strconv.FormatFloat(*record.Weight, 'f', -1, 64)
+		}
+		tags := strings.Join(record.Tags, csvTagSeparator)
+		fields := []string{record.Key, record.Section, record.QuestionText, record.ResponseType, weight, strconv.Itoa(record.Order), tags, record.Rating, record.Pack}
+		for _, language := range languages {
+			fields = append(fields, record.Translations[language].QuestionText)
+		}
+		if err := writer.Write(fields); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
+
+// normalizeQuestionRecord trims a record and puts questions without a section in the default section
+func normalizeQuestionRecord(record models.QuestionRecord) models.QuestionRecord {
+	record.Key = strings.TrimSpace(record.Key)
+	record.Section = models.NormalizeSectionName(record.Section)
+	if record.Section == "" {
+		record.Section = defaultSectionName
+	}
+	record.QuestionText = strings.TrimSpace(record.QuestionText)
+	record.ResponseType = strings.TrimSpace(record.ResponseType)
+	record.Tags = models.NormalizeTags(record.Tags)
+	record.Rating = strings.ToLower(strings.TrimSpace(record.Rating))
+	record.Pack = strings.ToLower(strings.TrimSpace(record.Pack))
+	if record.Translations != nil {
+		translations := make(map[string]models.QuestionTranslation, len(record.Translations))
+		for language, translation := range record.Translations {
+			translation.QuestionText = strings.TrimSpace(translation.QuestionText)
+			translations[strings.ToLower(strings.TrimSpace(language))] = translation
+		}
+		record.Translations = translations
+	}
+	return record
+}
+
+// validateQuestionRecord applies the same rules as creating a question through the API
+func validateQuestionRecord(record models.QuestionRecord) error {
+	if record.QuestionText == "" {
+		return fmt.Errorf("question text is required")
+	}
+	if record.ResponseType != "" && !models.IsValidAnswerScale(record.ResponseType) {
+		return fmt.Errorf("invalid response type %q", record.ResponseType)
+	}
+	if record.Weight != nil && !models.IsValidWeight(*record.Weight) {
+		return fmt.Errorf("weight must be greater than 0 and at most %g", float64(models.MaxWeight))
+	}
+	if record.Rating != "" && !models.IsValidRating(record.Rating) {
+		return fmt.Errorf("invalid rating %q", record.Rating)
+	}
+	return ValidateQuestionTranslations(record.Translations)
+}
+
+// applyQuestionRecord returns question with the fields of record; omitted optional fields are kept
+func applyQuestionRecord(question models.Question, record models.QuestionRecord) models.Question {
+	if record.Key != "" {
+		question.Key = record.Key
+	}
+	question.Section = record.Section
+	question.QuestionText = record.QuestionText
+	question.Order = record.Order
+	if record.ResponseType != "" {
+		question.ResponseType = record.ResponseType
+	}
+	if record.Weight != nil {
+		question.Weight = record.Weight
+	}
+	if len(record.Tags) > 0 {
+		question.Tags = record.Tags
+	}
+	if record.Rating != "" {
+		question.Rating = record.Rating
+	}
+	if len(record.Translations) > 0 {
+		question.Translations = record.Translations
+	}
+	return question
+}
+
+// questionUnchanged reports whether updated would store the same question as current
+func questionUnchanged(current, updated models.Question) bool {
+	sameWeight := (current.Weight == nil) == (updated.Weight == nil) &&
+		(current.Weight == nil || *current.Weight == *updated.Weight)
+	return current.Key == updated.Key &&
+		models.SectionKey(current.Section) == models.SectionKey(updated.Section) &&
+		current.QuestionText == updated.QuestionText &&
+		current.Order == updated.Order &&
+		current.Scale() == updated.Scale() &&
+		current.AudienceRating() == updated.AudienceRating() &&
+		strings.Join(current.Tags, ",") == strings.Join(updated.Tags, ",") &&
+		sameWeight &&
+		sameTranslations(current.Translations, updated.Translations)
+}
+
+// sameTranslations reports whether two questions have the same translations
+func sameTranslations(a, b map[string]models.QuestionTranslation) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for language, translation := range a {
+		if other, exists := b[language]; !exists || other != translation {
+			return false
+		}
+	}
+	return true
+}
+
+// packKey returns the key of the pack a question belongs to, given the keys of the packs by ID
+func packKey(question models.Question, packKeys map[primitive.ObjectID]string) string {
+	if question.PackID.IsZero() {
+		return models.DefaultPackKey
+	}
+	return packKeys[question.PackID]
+}
+
+// questionIdentity identifies a question by its section and text, ignoring case and spacing
+func questionIdentity(section, questionText string) string {
+	return models.SectionKey(section) + "\x00" + strings.ToLower(strings.Join(strings.Fields(questionText), " "))
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/seeds"
+)
+
+func TestQuestionBankCSVKeepsTranslations(t *testing.T) {
+	weight := 2.0
+	records := []models.QuestionRecord{
+		{
+			Key:          "food.sushi",
+			Section:      "Food",
+			QuestionText: "Sushi",
+			Weight:       &weight,
+			Order:        1,
+			Tags:         []string{"food", "japan"},
+			Pack:         models.DefaultPackKey,
+			Translations: map[string]models.QuestionTranslation{
+				"ro": {QuestionText: "Sushi, te rog"},
+				"es": {QuestionText: "Sushi, por favor"},
+			},
+		},
+		{
+			Key:          "travel.camping",
+			Section:      "Travel",
+			QuestionText: "Camping",
+			Translations: map[string]models.QuestionTranslation{
+				"es": {QuestionText: "Acampar"},
+			},
+		},
+		{Key: "travel.museums", Section: "Travel", QuestionText: "Visiting museums"},
+	}
+
+	data, err := encodeQuestionBankCSV(records)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	rows, err := parseQuestionBankCSV(data)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(rows) != len(records) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(records))
+	}
+
+	for i, row := range rows {
+		if row.err != nil {
+			t.Errorf("row %d: %v", i+1, row.err)
+			continue
+		}
+		got := normalizeQuestionRecord(row.record)
+		if err := validateQuestionRecord(got); err != nil {
+			t.Errorf("row %d is invalid: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(got, normalizeQuestionRecord(records[i])) {
+			t.Errorf("row %d = %+v, want %+v", i+1, got, records[i])
+		}
+	}
+}
+
+func TestEmbeddedSeedPacksAreTranslated(t *testing.T) {
+	pack, err := readSeedPack(seeds.Packs, "default.yaml")
+	if err != nil {
+		t.Fatalf("read default seed pack: %v", err)
+	}
+
+	for _, record := range pack.Questions {
+		for _, language := range translationLanguages() {
+			if record.Translations[language].QuestionText == "" {
+				t.Errorf("question %s has no %s translation", record.Key, language)
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// QuestionStatsService computes how players answer each question from the scored sessions
+type QuestionStatsService struct {
+	questionRepo   repositories.QuestionRepository
+	sessionRepo    repositories.GameSessionRepository
+	sectionService *SectionService
+}
+
+// NewQuestionStatsService creates a new question stats service
+func NewQuestionStatsService(questionRepo repositories.QuestionRepository, sessionRepo repositories.GameSessionRepository, sectionService *SectionService) *QuestionStatsService {
+	return &QuestionStatsService{
+		questionRepo:   questionRepo,
+		sessionRepo:    sessionRepo,
+		sectionService: sectionService,
+	}
+}
+
+// Stats computes the statistics of the filter's questions, or of every question when it names
+// none, in section order. Unknown question IDs are left out.
+func (s *QuestionStatsService) Stats(ctx context.Context, filter models.QuestionStatsFilter) ([]models.QuestionStats, error) {
+	var questions []models.Question
+	var err error
+	if len(filter.QuestionIDs) == 0 {
+		questions, err = s.sectionService.Questions(ctx, true)
+	} else {
+		questions, err = s.questionRepo.GetByIDs(ctx, filter.QuestionIDs)
+		if err == nil {
+			questions, err = s.sectionService.OrderQuestions(ctx, questions, true)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	responseCounts, err := s.sessionRepo.QuestionResponseCounts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	pairCounts, err := s.sessionRepo.QuestionPairCounts(ctx, filter, negativeResponses())
+	if err != nil {
+		return nil, err
+	}
+
+	countsByQuestion := make(map[primitive.ObjectID]map[string]int)
+	for _, count := range responseCounts {
+		if countsByQuestion[count.QuestionID] == nil {
+			countsByQuestion[count.QuestionID] = make(map[string]int)
+		}
+		countsByQuestion[count.QuestionID][count.Response] += count.Count
+	}
+	pairsByQuestion := make(map[primitive.ObjectID]models.QuestionPairCount, len(pairCounts))
+	for _, count := range pairCounts {
+		pairsByQuestion[count.QuestionID] = count
+	}
+
+	stats := make([]models.QuestionStats, 0, len(questions))
+	for _, question := range questions {
+		stats = append(stats, questionStats(question, countsByQuestion[question.ID], pairsByQuestion[question.ID]))
+	}
+	return stats, nil
+}
+
+// questionStats builds the statistics of one question from its response and pair counts
+func questionStats(question models.Question, counts map[string]int, pairs models.QuestionPairCount) models.QuestionStats {
+	// Every response of the scale is listed, followed by any given before the scale changed
+	responses := models.ScaleResponses(question.Scale())
+	onScale := make(map[string]bool, len(responses))
+	for _, response := range responses {
+		onScale[response] = true
+	}
+	for response := range counts {
+		if !onScale[response] {
+			responses = append(responses, response)
+		}
+	}
+
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+
+	distribution := make([]models.ResponseStat, 0, len(responses))
+	for _, response := range responses {
+		distribution = append(distribution, models.ResponseStat{
+			Response: response,
+			Count:    counts[response],
+			Share:    percentage(float64(counts[response]), float64(total)),
+		})
+	}
+	sort.SliceStable(distribution, func(i, j int) bool {
+		return distribution[i].Count > distribution[j].Count
+	})
+
+	stats := models.QuestionStats{
+		Question:      question,
+		Answers:       total,
+		Distribution:  distribution,
+		Pairs:         pairs.Pairs,
+		AgreementRate: percentage(float64(pairs.Agreed), float64(pairs.Pairs)),
+		Matches:       pairs.Matched,
+		MatchRate:     percentage(float64(pairs.Matched), float64(pairs.Pairs)),
+	}
+	if len(distribution) > 0 {
+		stats.TopShare = distribution[0].Share
+	}
+	return stats
+}
+
+// negativeResponses lists the negative responses of every scale, which never make a match
+func negativeResponses() []string {
+	var negative []string
+	for _, scale := range models.AllAnswerScales() {
+		for _, response := range models.ScaleResponses(scale) {
+			if models.IsNegativeResponse(response) {
+				negative = append(negative, response)
+			}
+		}
+	}
+	return negative
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"get-to-know-game-go/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// ErrSessionIncomplete is returned when building the results of a session still missing answers
+	ErrSessionIncomplete = errors.New("session is not complete")
+	// ErrScoreNotExplainable is returned when the session's scoring strategy can't be itemized
+	ErrScoreNotExplainable = errors.New("scores from this strategy can't be explained")
+)
+
+// ResultsService builds the result view of completed game sessions
+type ResultsService struct {
+	compatibilityService *CompatibilityService
+	predictionService    *PredictionService
+	frequencyService     *AnswerFrequencyService
+}
+
+// maxRareSharedAnswers is how many rare shared answers the results highlight
+const maxRareSharedAnswers = 3
+
+// NewResultsService creates a new results service
+func NewResultsService(compatibilityService *CompatibilityService, predictionService *PredictionService, frequencyService *AnswerFrequencyService) *ResultsService {
+	return &ResultsService{
+		compatibilityService: compatibilityService,
+		predictionService:    predictionService,
+		frequencyService:     frequencyService,
+	}
+}
+
+// BuildResults assembles the score, emoji tier and shared answers grouped by section.
+// Sections keep the order in which they first appear in questions. Only what the
+// spec allows is exposed: shared "Yay!" and "I don't care!" answers, never a "Nay!".
+// A non-empty alternateScorer adds the session's score under that strategy without
+// replacing the stored canonical score.
+func (s *ResultsService) BuildResults(ctx context.Context, session models.GameSession, questions []models.Question, player1Name, player2Name, alternateScorer string) (models.SessionResults, error) {
+	if !session.IsComplete() {
+		return models.SessionResults{}, ErrSessionIncomplete
+	}
+
+	frequencies, err := s.frequencyService.Frequencies(ctx, questions)
+	if err != nil {
+		return models.SessionResults{}, err
+	}
+
+	player1AnswerMap := make(map[string]string)
+	for _, answer := range session.Player1Answers {
+		player1AnswerMap[answer.QuestionID.Hex()] = answer.Response
+	}
+	player2AnswerMap := make(map[string]string)
+	for _, answer := range *session.Player2Answers {
+		player2AnswerMap[answer.QuestionID.Hex()] = answer.Response
+	}
+
+	sharedBySection := make(map[string][]models.SharedAnswer)
+	rareSharedAnswers := []models.RareSharedAnswer{}
+	for _, question := range questions {
+		player1Response := player1AnswerMap[question.ID.Hex()]
+		player2Response := player2AnswerMap[question.ID.Hex()]
+		if !s.compatibilityService.IsSharedAnswer(player1Response, player2Response) {
+			continue
+		}
+
+		if frequency, exists := frequencies[question.ID.Hex()]; exists && frequency.Total > 0 {
+			rareSharedAnswers = append(rareSharedAnswers, models.RareSharedAnswer{
+				QuestionID:   question.ID.Hex(),
+				QuestionText: question.QuestionText,
+				Response:     player1Response,
+				Share:        int(frequency.Share(player1Response)*100 + 0.5),
+			})
+		}
+
+		sharedBySection[question.Section] = append(sharedBySection[question.Section], models.SharedAnswer{
+			QuestionID:   question.ID.Hex(),
+			QuestionText: question.QuestionText,
+			Response:     player1Response,
+		})
+	}
+
+	sharedAnswers := []models.SectionSharedAnswers{}
+	for _, question := range questions {
+		answers, exists := sharedBySection[question.Section]
+		if !exists {
+			continue
+		}
+		sharedAnswers = append(sharedAnswers, models.SectionSharedAnswers{
+			Section: question.Section,
+			Answers: answers,
+		})
+		delete(sharedBySection, question.Section)
+	}
+
+	// The rarest first; answers everyone gives are no surprise
+	sort.SliceStable(rareSharedAnswers, func(i, j int) bool {
+		return rareSharedAnswers[i].Share < rareSharedAnswers[j].Share
+	})
+	if len(rareSharedAnswers) > maxRareSharedAnswers {
+		rareSharedAnswers = rareSharedAnswers[:maxRareSharedAnswers]
+	}
+
+	score := *session.CompatibilityScore
+	results := models.SessionResults{
+		SessionID:          session.ID.Hex(),
+		Mode:               session.GameMode(),
+		Player1Name:        player1Name,
+		Player2Name:        player2Name,
+		CompatibilityScore: score,
+		Emoji:              s.compatibilityService.ScoreEmoji(score),
+		ScoringStrategy:    session.ScoringStrategy,
+		ScoringVersion:     session.ScoringVersion,
+		SharedAnswers:      sharedAnswers,
+		RareSharedAnswers:  rareSharedAnswers,
+		SectionScores:      session.SectionScores,
+		Dealbreakers:       []models.DealbreakerResult{},
+		ScoreCapped:        session.ScoreCapped,
+	}
+
+	questionTexts := make(map[primitive.ObjectID]string, len(questions))
+	for _, question := range questions {
+		questionTexts[question.ID] = question.QuestionText
+	}
+	for _, dealbreaker := range session.Dealbreakers {
+		flaggedBy := player1Name
+		if dealbreaker.PlayerID != session.Player1ID {
+			flaggedBy = player2Name
+		}
+		results.Dealbreakers = append(results.Dealbreakers, models.DealbreakerResult{
+			QuestionID:   dealbreaker.QuestionID.Hex(),
+			QuestionText: questionTexts[dealbreaker.QuestionID],
+			FlaggedBy:    flaggedBy,
+		})
+	}
+
+	// Sessions scored before section breakdowns were stored get one calculated on the fly
+	if results.SectionScores == nil {
+		results.SectionScores = s.compatibilityService.CalculateSectionScores(session.Player1Answers, *session.Player2Answers, questions)
+	}
+
+	// Sessions scored before strategies were recorded used the spec formula
+	if results.ScoringStrategy == "" {
+		results.ScoringStrategy = DefaultScorerName
+		results.ScoringVersion = 1
+	}
+
+	if alternateScorer != "" {
+		alternate, scorer, err := s.compatibilityService.ScoreWith(alternateScorer, ScoreInput{
+			Player1Answers: session.Player1Answers,
+			Player2Answers: *session.Player2Answers,
+			Weights:        session.QuestionWeights,
+			Frequencies:    frequencies,
+		})
+		if err != nil {
+			return models.SessionResults{}, err
+		}
+		results.AlternateScore = &models.AlternateScore{
+			Scorer:             scorer.Name(),
+			Version:            scorer.Version(),
+			CompatibilityScore: alternate,
+			Emoji:              s.compatibilityService.ScoreEmoji(alternate),
+		}
+	}
+
+	if session.GameMode() == models.GameModePrediction {
+		player1Accuracy, player2Accuracy := s.predictionService.SessionAccuracy(session)
+		results.Player1Accuracy = &player1Accuracy
+		results.Player2Accuracy = &player2Accuracy
+	}
+
+	return results, nil
+}
+
+// ExplainScore itemizes a completed session's score question by question. Explanations follow
+// the spec formula, with the session's weights snapshot when it was scored by the weighted strategy.
+func (s *ResultsService) ExplainScore(session models.GameSession, questions []models.Question) (models.ScoreExplanation, error) {
+	if !session.IsComplete() {
+		return models.ScoreExplanation{}, ErrSessionIncomplete
+	}
+
+	strategy, version := session.ScoringStrategy, session.ScoringVersion
+	if strategy == "" {
+		strategy, version = DefaultScorerName, 1
+	}
+
+	var weights map[string]float64
+	switch strategy {
+	case DefaultScorerName:
+	case WeightedScorer{}.Name():
+		weights = session.QuestionWeights
+	default:
+		return models.ScoreExplanation{}, fmt.Errorf("%w: %s", ErrScoreNotExplainable, strategy)
+	}
+
+	items, totalPoints := s.compatibilityService.ExplainScore(session.Player1Answers, *session.Player2Answers, questions, weights)
+	return models.ScoreExplanation{
+		SessionID:          session.ID.Hex(),
+		ScoringStrategy:    strategy,
+		ScoringVersion:     version,
+		CompatibilityScore: *session.CompatibilityScore,
+		TotalPoints:        totalPoints,
+		ScoreCapped:        session.ScoreCapped,
+		Items:              items,
+	}, nil
+}
+
+// HasScorer reports whether name is an available scoring strategy
+func (s *ResultsService) HasScorer(name string) bool {
+	return s.compatibilityService.HasScorer(name)
+}
+
+// ScorerNames returns the names of the available scoring strategies
+func (s *ResultsService) ScorerNames() []string {
+	return s.compatibilityService.ScorerNames()
+}
@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxRoomParticipants caps room size; every pair is scored on each leaderboard request
+const maxRoomParticipants = 60
+
+var (
+	// ErrRoomClosed is returned when joining or answering in an expired room
+	ErrRoomClosed = errors.New("room has expired")
+	// ErrRoomFull is returned when a room has no space for another participant
+	ErrRoomFull = errors.New("room is full")
+	// ErrAlreadyAnswered is returned when a participant submits answers a second time
+	ErrAlreadyAnswered = errors.New("participant has already answered")
+)
+
+// RoomService runs hosted party rooms and ranks every pair of participants
+type RoomService struct {
+	roomRepo             repositories.RoomRepository
+	questionRepo         repositories.QuestionRepository
+	sectionService       *SectionService
+	packService          *PackService
+	audienceService      *AudienceService
+	compatibilityService *CompatibilityService
+	scoringService       *SessionScoringService
+	tokenService         *TokenService
+	joinCodeService      *JoinCodeService
+	roomTTL              time.Duration
+}
+
+// NewRoomService creates a new room service
+func NewRoomService(
+	roomRepo repositories.RoomRepository,
+	questionRepo repositories.QuestionRepository,
+	sectionService *SectionService,
+	packService *PackService,
+	audienceService *AudienceService,
+	compatibilityService *CompatibilityService,
+	scoringService *SessionScoringService,
+	tokenService *TokenService,
+	joinCodeService *JoinCodeService,
+	roomTTL time.Duration,
+) *RoomService {
+	return &RoomService{
+		roomRepo:             roomRepo,
+		questionRepo:         questionRepo,
+		sectionService:       sectionService,
+		packService:          packService,
+		audienceService:      audienceService,
+		compatibilityService: compatibilityService,
+		scoringService:       scoringService,
+		tokenService:         tokenService,
+		joinCodeService:      joinCodeService,
+		roomTTL:              roomTTL,
+	}
+}
+
+// CreateRoom opens a room over the published version of a question pack, by ID or key with empty
+// meaning the default pack, and returns it with the host's secret token
+func (s *RoomService) CreateRoom(ctx context.Context, name, packRef string) (models.Room, string, error) {
+	pack, err := s.packService.Pin(ctx, packRef)
+	if err != nil {
+		return models.Room{}, "", err
+	}
+
+	questions, err := s.packService.VersionQuestions(ctx, pack.ID, pack.PublishedVersion)
+	if err != nil {
+		return models.Room{}, "", err
+	}
+	questions = s.audienceService.Apply(questions, models.QuestionFilter{})
+
+	questionIDs := make([]primitive.ObjectID, 0, len(questions))
+	for _, question := range questions {
+		questionIDs = append(questionIDs, question.ID)
+	}
+
+	hostToken, hostTokenHash, err := s.tokenService.GenerateToken()
+	if err != nil {
+		return models.Room{}, "", err
+	}
+
+	now := time.Now().UTC()
+	room := models.Room{
+		ID:            primitive.NewObjectID(),
+		Name:          name,
+		HostTokenHash: hostTokenHash,
+		QuestionIDs:   questionIDs,
+		Participants:  []models.RoomParticipant{},
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(s.roomTTL),
+		PackID:        pack.ID,
+		PackVersion:   pack.PublishedVersion,
+	}
+
+	room.JoinCode, err = s.joinCodeService.IssueForRoom(ctx, room.ID, room.ExpiresAt)
+	if err != nil {
+		return models.Room{}, "", err
+	}
+
+	createdRoom, err := s.roomRepo.Create(ctx, room)
+	if err != nil {
+		if err := s.joinCodeService.ReleaseRoom(ctx, room.ID); err != nil {
+			log.Printf("Failed to release join code of room %s: %v", room.ID.Hex(), err)
+		}
+		return models.Room{}, "", err
+	}
+
+	return createdRoom, hostToken, nil
+}
+
+// GetRoom retrieves a room by ID
+func (s *RoomService) GetRoom(ctx context.Context, id string) (models.Room, error) {
+	return s.roomRepo.GetByID(ctx, id)
+}
+
+// Questions returns the room's shared question set in section order, without ratings excluded
+// on this deployment
+func (s *RoomService) Questions(ctx context.Context, room models.Room) ([]models.Question, error) {
+	var questions []models.Question
+	var err error
+	if !room.PackID.IsZero() {
+		questions, err = s.packService.VersionQuestions(ctx, room.PackID, room.PackVersion)
+	} else {
+		questions, err = s.questionRepo.GetByIDs(ctx, room.QuestionIDs)
+		if err == nil {
+			// The set was fixed when the room opened, so sections deactivated since then still count
+			questions, err = s.sectionService.OrderQuestions(ctx, questions, true)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.audienceService.Apply(questions, models.QuestionFilter{}), nil
+}
+
+// Join adds a participant to the room and returns them with their secret token
+func (s *RoomService) Join(ctx context.Context, room models.Room, name string) (models.RoomParticipant, string, error) {
+	if time.Now().After(room.ExpiresAt) {
+		return models.RoomParticipant{}, "", ErrRoomClosed
+	}
+	if len(room.Participants) >= maxRoomParticipants {
+		return models.RoomParticipant{}, "", ErrRoomFull
+	}
+
+	token, tokenHash, err := s.tokenService.GenerateToken()
+	if err != nil {
+		return models.RoomParticipant{}, "", err
+	}
+
+	participant := models.RoomParticipant{
+		ID:        primitive.NewObjectID(),
+		Name:      name,
+		TokenHash: tokenHash,
+	}
+
+	added, err := s.roomRepo.AddParticipant(ctx, room.ID.Hex(), participant, maxRoomParticipants)
+	if err != nil {
+		return models.RoomParticipant{}, "", err
+	}
+	if !added {
+		return models.RoomParticipant{}, "", ErrRoomFull
+	}
+
+	return participant, token, nil
+}
+
+// SubmitAnswers stores a participant's answers to every question of the room
+func (s *RoomService) SubmitAnswers(ctx context.Context, room models.Room, participant models.RoomParticipant, answers []models.PlayerAnswer) error {
+	if time.Now().After(room.ExpiresAt) {
+		return ErrRoomClosed
+	}
+	if participant.HasAnswered() {
+		return ErrAlreadyAnswered
+	}
+
+	questions, err := s.Questions(ctx, room)
+	if err != nil {
+		return err
+	}
+	if err := validateAnswerSet(questions, answers, false); err != nil {
+		return err
+	}
+
+	stored, err := s.roomRepo.SetParticipantAnswers(ctx, room.ID.Hex(), participant.ID, answers, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if !stored {
+		return ErrAlreadyAnswered
+	}
+
+	return nil
+}
+
+// Leaderboard scores every pair of participants who have answered and returns the best limit pairs
+func (s *RoomService) Leaderboard(ctx context.Context, room models.Room, limit int) ([]models.RoomPair, error) {
+	answered := answeredParticipants(room)
+
+	input, err := s.scoreInput(ctx, room)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := []models.RoomPair{}
+	for i := 0; i < len(answered); i++ {
+		for j := i + 1; j < len(answered); j++ {
+			score, err := s.scorePair(input, answered[i], answered[j])
+			if err != nil {
+				return nil, err
+			}
+			pairs = append(pairs, models.RoomPair{
+				Participant1ID:     answered[i].ID.Hex(),
+				Participant1Name:   answered[i].Name,
+				Participant2ID:     answered[j].ID.Hex(),
+				Participant2Name:   answered[j].Name,
+				CompatibilityScore: score,
+				Emoji:              s.compatibilityService.ScoreEmoji(score),
+			})
+		}
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].CompatibilityScore > pairs[j].CompatibilityScore
+	})
+
+	if limit > 0 && len(pairs) > limit {
+		pairs = pairs[:limit]
+	}
+	return pairs, nil
+}
+
+// TopMatches returns the limit participants who are most compatible with participant
+func (s *RoomService) TopMatches(ctx context.Context, room models.Room, participant models.RoomParticipant, limit int) ([]models.RoomMatch, error) {
+	matches := []models.RoomMatch{}
+	if !participant.HasAnswered() {
+		return matches, nil
+	}
+
+	input, err := s.scoreInput(ctx, room)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, other := range answeredParticipants(room) {
+		if other.ID == participant.ID {
+			continue
+		}
+		score, err := s.scorePair(input, participant, other)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, models.RoomMatch{
+			ParticipantID:      other.ID.Hex(),
+			Name:               other.Name,
+			CompatibilityScore: score,
+			Emoji:              s.compatibilityService.ScoreEmoji(score),
+		})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].CompatibilityScore > matches[j].CompatibilityScore
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// scoreInput returns the weights and answer frequencies of the room's questions, shared by every pair
+func (s *RoomService) scoreInput(ctx context.Context, room models.Room) (ScoreInput, error) {
+	questions, err := s.Questions(ctx, room)
+	if err != nil {
+		return ScoreInput{}, err
+	}
+	return s.scoringService.scoreInput(ctx, questions)
+}
+
+// scorePair scores two participants the way sessions are scored, so the configured strategy and
+// the dealbreaker cap apply in rooms too
+func (s *RoomService) scorePair(input ScoreInput, participant1, participant2 models.RoomParticipant) (int, error) {
+	input.Player1Answers, input.Player2Answers = participant1.Answers, participant2.Answers
+	result, err := s.scoringService.scorePair(input, participant1.ID, participant2.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.score, nil
+}
+
+// answeredParticipants returns the participants of the room who have submitted answers
+func answeredParticipants(room models.Room) []models.RoomParticipant {
+	var answered []models.RoomParticipant
+	for _, participant := range room.Participants {
+		if participant.HasAnswered() {
+			answered = append(answered, participant)
+		}
+	}
+	return answered
+}
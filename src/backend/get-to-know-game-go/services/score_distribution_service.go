@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/repositories"
+)
+
+// ScoreDistributionService keeps the distribution of scores over completed sessions,
+// overall and per question set, so a score can be compared against other pairs
+type ScoreDistributionService struct {
+	distributionRepo repositories.ScoreDistributionRepository
+	sessionRepo      repositories.GameSessionRepository
+}
+
+// NewScoreDistributionService creates a new score distribution service
+func NewScoreDistributionService(distributionRepo repositories.ScoreDistributionRepository, sessionRepo repositories.GameSessionRepository) *ScoreDistributionService {
+	return &ScoreDistributionService{
+		distributionRepo: distributionRepo,
+		sessionRepo:      sessionRepo,
+	}
+}
+
+// Record adds a newly scored session to the distributions. session is the session as it was
+// before scoring, so a session scored again moves from its previous score instead of counting twice.
+func (s *ScoreDistributionService) Record(ctx context.Context, session models.GameSession, questionSetKey string, score int) error {
+	if session.CompatibilityScore != nil {
+		for _, key := range distributionKeys(session.QuestionSetKey) {
+			if err := s.distributionRepo.Increment(ctx, key, *session.CompatibilityScore, -1); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, key := range distributionKeys(questionSetKey) {
+		if err := s.distributionRepo.Increment(ctx, key, score, 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Backfill builds the distributions from every scored session when none exist yet,
+// covering sessions completed before distributions were kept. It runs before the server
+// listens; distributions another instance has started meanwhile are not overwritten.
+func (s *ScoreDistributionService) Backfill(ctx context.Context) error {
+	count, err := s.distributionRepo.Count(ctx)
+	if err != nil || count > 0 {
+		return err
+	}
+
+	scoreCounts, err := s.sessionRepo.ScoreCounts(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	distributions := make(map[string]*models.ScoreDistribution)
+	for _, scoreCount := range scoreCounts {
+		for _, key := range distributionKeys(scoreCount.QuestionSetKey) {
+			distribution, exists := distributions[key]
+			if !exists {
+				distribution = &models.ScoreDistribution{Key: key, Counts: make(map[string]int), UpdatedAt: now}
+				distributions[key] = distribution
+			}
+			distribution.Counts[strconv.Itoa(scoreCount.Score)] += scoreCount.Count
+			distribution.Total += scoreCount.Count
+		}
+	}
+
+	result := make([]models.ScoreDistribution, 0, len(distributions))
+	for _, distribution := range distributions {
+		result = append(result, *distribution)
+	}
+	return s.distributionRepo.InsertMissing(ctx, result)
+}
+
+// Distribution returns the distribution stored under key
+func (s *ScoreDistributionService) Distribution(ctx context.Context, key string) (models.ScoreDistribution, error) {
+	return s.distributionRepo.GetByKey(ctx, key)
+}
+
+// Distributions returns every stored distribution
+func (s *ScoreDistributionService) Distributions(ctx context.Context) ([]models.ScoreDistribution, error) {
+	return s.distributionRepo.GetAll(ctx)
+}
+
+// Percentiles returns the percentage of pairs a completed session outscored, overall and among
+// sessions with the same question set. A percentile is nil when there is no distribution for it.
+func (s *ScoreDistributionService) Percentiles(ctx context.Context, session models.GameSession) (*int, *int) {
+	if session.CompatibilityScore == nil {
+		return nil, nil
+	}
+
+	var overall, questionSet *int
+	if distribution, err := s.distributionRepo.GetByKey(ctx, models.OverallDistributionKey); err == nil && distribution.Total > 0 {
+		percentile := distribution.PercentileOf(*session.CompatibilityScore)
+		overall = &percentile
+	}
+	if session.QuestionSetKey != "" {
+		if distribution, err := s.distributionRepo.GetByKey(ctx, session.QuestionSetKey); err == nil && distribution.Total > 0 {
+			percentile := distribution.PercentileOf(*session.CompatibilityScore)
+			questionSet = &percentile
+		}
+	}
+	return overall, questionSet
+}
+
+// distributionKeys returns the distributions a session with questionSetKey counts towards
+func distributionKeys(questionSetKey string) []string {
+	if questionSetKey == "" {
+		return []string{models.OverallDistributionKey}
+	}
+	return []string{models.OverallDistributionKey, questionSetKey}
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"get-to-know-game-go/models"
+)
+
+// DefaultScorerName is the strategy used for the canonical score of a session
+const DefaultScorerName = "spec"
+
+// ScoreInput holds everything a scoring strategy may use to score a pair of players
+type ScoreInput struct {
+	Player1Answers []models.PlayerAnswer
+	Player2Answers []models.PlayerAnswer
+	// Weights maps question IDs to their weight; questions without one count as models.DefaultWeight
+	Weights map[string]float64
+	// Frequencies maps question IDs to how often each response is given, for rarity scoring
+	Frequencies map[string]models.AnswerFrequency
+}
+
+// rarity returns how rare response is for a question, 1 when no frequencies are known
+func (input ScoreInput) rarity(questionID, response string) float64 {
+	return input.Frequencies[questionID].Rarity(response)
+}
+
+// weight returns the weight of a question in the input
+func (input ScoreInput) weight(questionID string) float64 {
+	if weight, exists := input.Weights[questionID]; exists {
+		return weight
+	}
+	return models.DefaultWeight
+}
+
+// Scorer is a named, versioned compatibility scoring strategy producing a score from 0 to 100.
+// Bump the version whenever the formula changes so stored scores can be told apart.
+type Scorer interface {
+	Name() string
+	Version() int
+	Score(input ScoreInput) (int, error)
+}
+
+// ScorerRegistry holds the scoring strategies available by name
+type ScorerRegistry struct {
+	scorers map[string]Scorer
+}
+
+// NewScorerRegistry creates a registry with the given scorers
+func NewScorerRegistry(scorers ...Scorer) *ScorerRegistry {
+	registry := &ScorerRegistry{scorers: make(map[string]Scorer)}
+	for _, scorer := range scorers {
+		registry.Register(scorer)
+	}
+	return registry
+}
+
+// NewDefaultScorerRegistry creates a registry with every built-in scoring strategy
+func NewDefaultScorerRegistry() *ScorerRegistry {
+	return NewScorerRegistry(
+		SpecScorer{},
+		JaccardScorer{},
+		AgreementScorer{},
+		KappaScorer{},
+		WeightedScorer{},
+		RarityScorer{},
+	)
+}
+
+// Register adds a scorer, replacing any scorer registered under the same name
+func (r *ScorerRegistry) Register(scorer Scorer) {
+	r.scorers[scorer.Name()] = scorer
+}
+
+// Get returns the scorer registered under name
+func (r *ScorerRegistry) Get(name string) (Scorer, error) {
+	scorer, exists := r.scorers[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown scorer: %s", name)
+	}
+	return scorer, nil
+}
+
+// Names returns the names of all registered scorers in alphabetical order
+func (r *ScorerRegistry) Names() []string {
+	names := make([]string, 0, len(r.scorers))
+	for name := range r.scorers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// responsePair is one question answered by both players
+type responsePair struct {
+	questionID      string
+	player1Response string
+	player2Response string
+}
+
+// pairResponses matches player 1's answers with player 2's answers to the same questions
+func pairResponses(input ScoreInput) ([]responsePair, error) {
+	if len(input.Player1Answers) != len(input.Player2Answers) {
+		return nil, fmt.Errorf("both players must answer the same number of questions")
+	}
+
+	player2AnswerMap := make(map[string]string)
+	for _, answer := range input.Player2Answers {
+		player2AnswerMap[answer.QuestionID.Hex()] = answer.Response
+	}
+
+	pairs := make([]responsePair, 0, len(input.Player1Answers))
+	for _, answer := range input.Player1Answers {
+		player2Response, exists := player2AnswerMap[answer.QuestionID.Hex()]
+		if !exists {
+			continue
+		}
+		pairs = append(pairs, responsePair{
+			questionID:      answer.QuestionID.Hex(),
+			player1Response: answer.Response,
+			player2Response: player2Response,
+		})
+	}
+	return pairs, nil
+}
+
+// percentage converts a fraction to a whole-number percentage, rounding to nearest
+func percentage(part, total float64) int {
+	if total == 0 {
+		return 0
+	}
+	return int(part/total*100 + 0.5)
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"math"
+
+	"get-to-know-game-go/models"
+)
+
+// SpecScorer is the formula from the game spec: the share of questions where both
+// players said "Yay!" or both said "I don't care!". A shared "Nay!" doesn't count.
+// Likert questions earn partial credit by how close the two answers are, unless both disagree.
+type SpecScorer struct{}
+
+// Name returns the strategy name
+func (SpecScorer) Name() string { return DefaultScorerName }
+
+// Version returns the strategy version
+func (SpecScorer) Version() int { return 3 }
+
+// Score calculates the spec compatibility score
+func (SpecScorer) Score(input ScoreInput) (int, error) {
+	pairs, err := pairResponses(input)
+	if err != nil {
+		return 0, err
+	}
+
+	matches := 0.0
+	for _, pair := range pairs {
+		matches += answerCredit(pair.player1Response, pair.player2Response)
+	}
+	return percentage(matches, float64(len(input.Player1Answers))), nil
+}
+
+// JaccardScorer scores the overlap of the things both players like: shared positive
+// answers ("Yay!", "Yes", agreeing) divided by the questions where at least one of them
+// answered positively.
+type JaccardScorer struct{}
+
+// Name returns the strategy name
+func (JaccardScorer) Name() string { return "jaccard" }
+
+// Version returns the strategy version
+func (JaccardScorer) Version() int { return 2 }
+
+// Score calculates the Jaccard index over "Yay!" answers
+func (JaccardScorer) Score(input ScoreInput) (int, error) {
+	pairs, err := pairResponses(input)
+	if err != nil {
+		return 0, err
+	}
+
+	shared, union := 0, 0
+	for _, pair := range pairs {
+		yay1, yay2 := models.IsPositiveResponse(pair.player1Response), models.IsPositiveResponse(pair.player2Response)
+		if yay1 && yay2 {
+			shared++
+		}
+		if yay1 || yay2 {
+			union++
+		}
+	}
+	return percentage(float64(shared), float64(union)), nil
+}
+
+// AgreementScorer is the share of questions both players answered the same way,
+// counting shared "Nay!" answers too. Likert questions earn partial credit by distance.
+type AgreementScorer struct{}
+
+// Name returns the strategy name
+func (AgreementScorer) Name() string { return "agreement" }
+
+// Version returns the strategy version
+func (AgreementScorer) Version() int { return 2 }
+
+// Score calculates the raw agreement rate
+func (AgreementScorer) Score(input ScoreInput) (int, error) {
+	pairs, err := pairResponses(input)
+	if err != nil {
+		return 0, err
+	}
+
+	agreements := 0.0
+	for _, pair := range pairs {
+		point1, likert1 := models.LikertPoint(pair.player1Response)
+		point2, likert2 := models.LikertPoint(pair.player2Response)
+		switch {
+		case likert1 && likert2:
+			agreements += models.LikertCredit(point1, point2)
+		case pair.player1Response == pair.player2Response:
+			agreements++
+		}
+	}
+	return percentage(agreements, float64(len(input.Player1Answers))), nil
+}
+
+// KappaScorer is Cohen's kappa: agreement corrected for the agreement expected by
+// chance from each player's own answer mix. Agreement no better than chance scores 0.
+type KappaScorer struct{}
+
+// Name returns the strategy name
+func (KappaScorer) Name() string { return "kappa" }
+
+// Version returns the strategy version
+func (KappaScorer) Version() int { return 1 }
+
+// Score calculates Cohen's kappa scaled to 0–100
+func (KappaScorer) Score(input ScoreInput) (int, error) {
+	pairs, err := pairResponses(input)
+	if err != nil {
+		return 0, err
+	}
+	if len(pairs) == 0 {
+		return 0, nil
+	}
+
+	agreements := 0
+	player1Counts := make(map[string]int)
+	player2Counts := make(map[string]int)
+	for _, pair := range pairs {
+		if pair.player1Response == pair.player2Response {
+			agreements++
+		}
+		player1Counts[pair.player1Response]++
+		player2Counts[pair.player2Response]++
+	}
+
+	total := float64(len(pairs))
+	observed := float64(agreements) / total
+	expected := 0.0
+	for response, count := range player1Counts {
+		expected += float64(count) / total * float64(player2Counts[response]) / total
+	}
+
+	// Both players gave one identical answer throughout, so chance explains everything
+	if expected >= 1 {
+		if observed >= 1 {
+			return 100, nil
+		}
+		return 0, nil
+	}
+
+	kappa := (observed - expected) / (1 - expected)
+	if kappa < 0 {
+		kappa = 0
+	}
+	return percentage(kappa, 1), nil
+}
+
+// WeightedScorer is the spec formula with each question counting its weight instead of one,
+// normalized to 0–100 by the total weight of the answered questions.
+type WeightedScorer struct{}
+
+// Name returns the strategy name
+func (WeightedScorer) Name() string { return "weighted" }
+
+// Version returns the strategy version
+func (WeightedScorer) Version() int { return 3 }
+
+// Score calculates the weighted spec score
+func (WeightedScorer) Score(input ScoreInput) (int, error) {
+	pairs, err := pairResponses(input)
+	if err != nil {
+		return 0, err
+	}
+
+	matched, total := 0.0, 0.0
+	for _, pair := range pairs {
+		weight := input.weight(pair.questionID)
+		total += weight
+		matched += weight * answerCredit(pair.player1Response, pair.player2Response)
+	}
+	return percentage(matched, total), nil
+}
+
+// RarityScorer weights each question by how rare the players' responses are across all
+// stored answers, in the style of IDF: sharing an answer few people give counts for more.
+// A question is worth the rarity of the rarer of the two responses, earned in full by a shared answer.
+type RarityScorer struct{}
+
+// Name returns the strategy name
+func (RarityScorer) Name() string { return "rarity" }
+
+// Version returns the strategy version
+func (RarityScorer) Version() int { return 2 }
+
+// Score calculates the rarity-weighted score
+func (RarityScorer) Score(input ScoreInput) (int, error) {
+	pairs, err := pairResponses(input)
+	if err != nil {
+		return 0, err
+	}
+
+	matched, total := 0.0, 0.0
+	for _, pair := range pairs {
+		rarity1 := input.rarity(pair.questionID, pair.player1Response)
+		rarity2 := input.rarity(pair.questionID, pair.player2Response)
+		total += math.Max(rarity1, rarity2)
+		matched += answerCredit(pair.player1Response, pair.player2Response) * (rarity1 + rarity2) / 2
+	}
+	return percentage(matched, total), nil
+}
+
+// isSharedAnswer reports whether two responses are the same answer and not a negative one,
+// such as both "Yay!" or both "I don't care!"; a shared "Nay!" is never a shared answer
+func isSharedAnswer(player1Response, player2Response string) bool {
+	return player1Response == player2Response && !models.IsNegativeResponse(player1Response)
+}
+
+// answerCredit returns how much a question counts towards the spec score, from 0 to 1.
+// Likert answers earn partial credit by distance; other scales score 1 for a shared answer.
+// Two negative answers never count, so both disagreeing on the Likert scale scores 0 like a shared "Nay!".
+func answerCredit(player1Response, player2Response string) float64 {
+	if models.IsNegativeResponse(player1Response) && models.IsNegativeResponse(player2Response) {
+		return 0
+	}
+	point1, likert1 := models.LikertPoint(player1Response)
+	point2, likert2 := models.LikertPoint(player2Response)
+	if likert1 && likert2 {
+		return models.LikertCredit(point1, point2)
+	}
+	if isSharedAnswer(player1Response, player2Response) {
+		return 1
+	}
+	return 0
+}
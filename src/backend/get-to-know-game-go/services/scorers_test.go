@@ -0,0 +1,256 @@
+package services
+
+import (
+	"testing"
+
+	"get-to-know-game-go/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// scorerQuestionIDs are the questions of the scorer tests; weights and frequencies refer to them
+var scorerQuestionIDs = []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()}
+
+// pairedInput builds a score input where both players answered the first questions, in order
+func pairedInput(player1Responses, player2Responses []string) ScoreInput {
+	input := ScoreInput{
+		Player1Answers: []models.PlayerAnswer{},
+		Player2Answers: []models.PlayerAnswer{},
+	}
+	for i, response := range player1Responses {
+		input.Player1Answers = append(input.Player1Answers, models.PlayerAnswer{QuestionID: scorerQuestionIDs[i], Response: response})
+	}
+	for i, response := range player2Responses {
+		input.Player2Answers = append(input.Player2Answers, models.PlayerAnswer{QuestionID: scorerQuestionIDs[i], Response: response})
+	}
+	return input
+}
+
+type scorerTest struct {
+	name        string
+	player1     []string
+	player2     []string
+	weights     map[string]float64
+	frequencies map[string]models.AnswerFrequency
+	want        int
+}
+
+func runScorerTests(t *testing.T, scorer Scorer, tests []scorerTest) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := pairedInput(tt.player1, tt.player2)
+			input.Weights = tt.weights
+			input.Frequencies = tt.frequencies
+
+			got, err := scorer.Score(input)
+			if err != nil {
+				t.Fatalf("Score: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("%s score = %d, want %d", scorer.Name(), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpecScorer(t *testing.T) {
+	runScorerTests(t, SpecScorer{}, []scorerTest{
+		{name: "no answers", want: 0},
+		{
+			name:    "shared yay and dont care",
+			player1: []string{models.Yay, models.DontCare, models.Nay, models.Yay},
+			player2: []string{models.Yay, models.DontCare, models.Nay, models.Nay},
+			want:    50,
+		},
+		{
+			name:    "all negative",
+			player1: []string{models.Nay, models.No, "1", "2"},
+			player2: []string{models.Nay, models.No, "1", "2"},
+			want:    0,
+		},
+		{name: "likert neighbours", player1: []string{"5"}, player2: []string{"4"}, want: 75},
+		{name: "likert opposite ends", player1: []string{"5"}, player2: []string{"1"}, want: 0},
+		{name: "likert both disagree", player1: []string{"1"}, player2: []string{"2"}, want: 0},
+		{name: "likert neutral", player1: []string{"3"}, player2: []string{"3"}, want: 100},
+		{name: "likert one disagrees", player1: []string{"2"}, player2: []string{"3"}, want: 75},
+	})
+}
+
+func TestJaccardScorer(t *testing.T) {
+	runScorerTests(t, JaccardScorer{}, []scorerTest{
+		{name: "no answers", want: 0},
+		{
+			name:    "all negative",
+			player1: []string{models.Nay, models.No},
+			player2: []string{models.Nay, models.No},
+			want:    0,
+		},
+		{
+			name:    "half the likes shared",
+			player1: []string{models.Yay, models.Yay, models.Nay},
+			player2: []string{models.Yay, models.Nay, models.Nay},
+			want:    50,
+		},
+		{
+			name:    "dont care left out",
+			player1: []string{models.DontCare, models.Yes},
+			player2: []string{models.DontCare, models.Yes},
+			want:    100,
+		},
+		{
+			name:    "nothing liked in common",
+			player1: []string{models.Yay, models.Nay},
+			player2: []string{models.Nay, models.Yay},
+			want:    0,
+		},
+	})
+}
+
+func TestAgreementScorer(t *testing.T) {
+	runScorerTests(t, AgreementScorer{}, []scorerTest{
+		{name: "no answers", want: 0},
+		{
+			name:    "all negative",
+			player1: []string{models.Nay, models.No},
+			player2: []string{models.Nay, models.No},
+			want:    100,
+		},
+		{
+			name:    "half agreeing",
+			player1: []string{models.Yay, models.DontCare, models.Nay, models.Yay},
+			player2: []string{models.Yay, models.Nay, models.Nay, models.Nay},
+			want:    50,
+		},
+		{
+			name:    "never agreeing",
+			player1: []string{models.Yay, models.Yes},
+			player2: []string{models.DontCare, models.No},
+			want:    0,
+		},
+	})
+}
+
+func TestKappaScorer(t *testing.T) {
+	runScorerTests(t, KappaScorer{}, []scorerTest{
+		{name: "no answers", want: 0},
+		{
+			name:    "perfect agreement",
+			player1: []string{models.Yay, models.Nay},
+			player2: []string{models.Yay, models.Nay},
+			want:    100,
+		},
+		{
+			name:    "better than chance",
+			player1: []string{models.Yay, models.Yay, models.Nay, models.Nay},
+			player2: []string{models.Yay, models.Nay, models.Nay, models.Nay},
+			want:    50,
+		},
+		{
+			name:    "worse than chance",
+			player1: []string{models.Yay, models.Nay},
+			player2: []string{models.Nay, models.Yay},
+			want:    0,
+		},
+		{
+			name:    "zero denominator",
+			player1: []string{models.Yay, models.Yay, models.Yay},
+			player2: []string{models.Yay, models.Yay, models.Yay},
+			want:    100,
+		},
+		{
+			name:    "all negative",
+			player1: []string{models.Nay, models.Nay},
+			player2: []string{models.Nay, models.Nay},
+			want:    100,
+		},
+		{
+			name:    "never agreeing",
+			player1: []string{models.Yay, models.Yay},
+			player2: []string{models.Nay, models.Nay},
+			want:    0,
+		},
+	})
+}
+
+func TestWeightedScorer(t *testing.T) {
+	runScorerTests(t, WeightedScorer{}, []scorerTest{
+		{name: "no answers", want: 0},
+		{
+			name:    "heavy question shared",
+			player1: []string{models.Yay, models.Yay},
+			player2: []string{models.Yay, models.Nay},
+			weights: map[string]float64{scorerQuestionIDs[0].Hex(): 3},
+			want:    75,
+		},
+		{
+			name:    "light question shared",
+			player1: []string{models.Yay, models.Yay},
+			player2: []string{models.Nay, models.Yay},
+			weights: map[string]float64{scorerQuestionIDs[0].Hex(): 3},
+			want:    25,
+		},
+		{
+			name:    "all negative",
+			player1: []string{models.Nay, models.No},
+			player2: []string{models.Nay, models.No},
+			weights: map[string]float64{scorerQuestionIDs[0].Hex(): 3},
+			want:    0,
+		},
+		{
+			name:    "likert partial credit",
+			player1: []string{"5", "1"},
+			player2: []string{"4", "1"},
+			weights: map[string]float64{scorerQuestionIDs[0].Hex(): 2},
+			want:    50,
+		},
+	})
+}
+
+func TestRarityScorer(t *testing.T) {
+	// One in ten players answers the first question "Nay!" or "I don't care!"
+	rareNay := map[string]models.AnswerFrequency{
+		scorerQuestionIDs[0].Hex(): {Counts: map[string]int{models.Yay: 9, models.Nay: 1}, Total: 10},
+	}
+	rareDontCare := map[string]models.AnswerFrequency{
+		scorerQuestionIDs[0].Hex(): {Counts: map[string]int{models.Yay: 9, models.DontCare: 1}, Total: 10},
+	}
+
+	runScorerTests(t, RarityScorer{}, []scorerTest{
+		{name: "no answers", want: 0},
+		{
+			name:    "empty frequencies",
+			player1: []string{models.Yay, models.Nay},
+			player2: []string{models.Yay, models.Yay},
+			want:    50,
+		},
+		{
+			name:        "all negative",
+			player1:     []string{models.Nay, models.Nay},
+			player2:     []string{models.Nay, models.Nay},
+			frequencies: rareNay,
+			want:        0,
+		},
+		{
+			name:        "rare shared answer",
+			player1:     []string{models.DontCare, models.Yay},
+			player2:     []string{models.DontCare, models.Nay},
+			frequencies: rareDontCare,
+			want:        73,
+		},
+		{
+			name:        "common shared answer",
+			player1:     []string{models.Yay, models.Yay},
+			player2:     []string{models.Yay, models.Nay},
+			frequencies: rareDontCare,
+			want:        52,
+		},
+		{
+			name:        "rare shared negative",
+			player1:     []string{models.Nay, models.Yay},
+			player2:     []string{models.Nay, models.Yay},
+			frequencies: rareNay,
+			want:        27,
+		},
+	})
+}
@@ -0,0 +1,397 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Section errors the handlers map to HTTP responses
+var (
+	ErrSectionExists         = errors.New("a section with this name already exists")
+	ErrSectionInUse          = errors.New("section still has questions")
+	ErrSectionNotFound       = errors.New("section not found")
+	ErrSectionWeightNotFound = errors.New("section weight not found")
+	ErrSectionNameRequired   = errors.New("section name is required")
+)
+
+// defaultSectionName is the section of questions created without one
+const defaultSectionName = "General"
+
+// SectionService manages question sections and the order questions are served in
+type SectionService struct {
+	sectionRepo       repositories.SectionRepository
+	questionRepo      repositories.QuestionRepository
+	sectionWeightRepo repositories.SectionWeightRepository
+}
+
+// NewSectionService creates a new section service. sectionWeightRepo holds the section weights
+// kept by name before they were stored on the sections, and is only read to migrate them.
+func NewSectionService(sectionRepo repositories.SectionRepository, questionRepo repositories.QuestionRepository, sectionWeightRepo repositories.SectionWeightRepository) *SectionService {
+	return &SectionService{
+		sectionRepo:       sectionRepo,
+		questionRepo:      questionRepo,
+		sectionWeightRepo: sectionWeightRepo,
+	}
+}
+
+// ListSections returns the sections in display order, leaving out inactive ones unless includeInactive is set
+func (s *SectionService) ListSections(ctx context.Context, includeInactive bool) ([]models.Section, error) {
+	sections, err := s.sectionRepo.GetAllOrdered(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if includeInactive {
+		return sections, nil
+	}
+
+	active := []models.Section{}
+	for _, section := range sections {
+		if section.Active {
+			active = append(active, section)
+		}
+	}
+	return active, nil
+}
+
+// GetSection retrieves a section by ID
+func (s *SectionService) GetSection(ctx context.Context, id string) (models.Section, error) {
+	return s.sectionRepo.GetByID(ctx, id)
+}
+
+// CreateSection creates a section, placing it last unless a display order is given
+func (s *SectionService) CreateSection(ctx context.Context, req models.CreateSectionRequest) (models.Section, error) {
+	name := models.NormalizeSectionName(req.Name)
+	if name == "" {
+		return models.Section{}, ErrSectionNameRequired
+	}
+	if err := validateTranslationLanguages(req.Translations); err != nil {
+		return models.Section{}, err
+	}
+
+	section := models.Section{
+		Key:          models.SectionKey(name),
+		Name:         name,
+		Description:  req.Description,
+		Icon:         req.Icon,
+		Active:       req.Active == nil || *req.Active,
+		Translations: req.Translations,
+	}
+	if req.DisplayOrder != nil {
+		section.DisplayOrder = *req.DisplayOrder
+	} else {
+		displayOrder, err := s.nextDisplayOrder(ctx)
+		if err != nil {
+			return models.Section{}, err
+		}
+		section.DisplayOrder = displayOrder
+	}
+
+	createdSection, err := s.sectionRepo.Create(ctx, section)
+	if mongo.IsDuplicateKeyError(err) {
+		return models.Section{}, ErrSectionExists
+	}
+	return createdSection, err
+}
+
+// UpdateSection updates a section; a new name is carried over to its questions
+func (s *SectionService) UpdateSection(ctx context.Context, id string, req models.UpdateSectionRequest) (models.Section, error) {
+	section, err := s.sectionRepo.GetByID(ctx, id)
+	if err != nil {
+		return models.Section{}, err
+	}
+
+	oldName := section.Name
+	section, err = applySectionUpdate(section, req)
+	if err != nil {
+		return models.Section{}, err
+	}
+	name := section.Name
+
+	if err := s.sectionRepo.Update(ctx, id, section); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.Section{}, ErrSectionExists
+		}
+		return models.Section{}, err
+	}
+
+	if name != oldName {
+		if err := s.questionRepo.RenameSection(ctx, section.ID, name); err != nil {
+			return models.Section{}, err
+		}
+	}
+
+	return section, nil
+}
+
+// applySectionUpdate returns section with the fields given in req changed
+func applySectionUpdate(section models.Section, req models.UpdateSectionRequest) (models.Section, error) {
+	name := models.NormalizeSectionName(req.Name)
+	if name == "" {
+		return models.Section{}, ErrSectionNameRequired
+	}
+	if err := validateTranslationLanguages(req.Translations); err != nil {
+		return models.Section{}, err
+	}
+
+	section.Key = models.SectionKey(name)
+	section.Name = name
+	if req.Description != nil {
+		section.Description = *req.Description
+	}
+	if req.Icon != nil {
+		section.Icon = *req.Icon
+	}
+	if req.DisplayOrder != nil {
+		section.DisplayOrder = *req.DisplayOrder
+	}
+	if req.Active != nil {
+		section.Active = *req.Active
+	}
+	if req.Translations != nil {
+		section.Translations = req.Translations
+	}
+	return section, nil
+}
+
+// DeleteSection removes a section that no longer has questions
+func (s *SectionService) DeleteSection(ctx context.Context, id string) error {
+	section, err := s.sectionRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.questionRepo.CountBySection(ctx, section.ID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrSectionInUse
+	}
+
+	return s.sectionRepo.Delete(ctx, id)
+}
+
+// ResolveSection returns the section called name, creating an active one at the end if there is none.
+// An empty name resolves to the default section.
+func (s *SectionService) ResolveSection(ctx context.Context, name string) (models.Section, error) {
+	if models.NormalizeSectionName(name) == "" {
+		name = defaultSectionName
+	}
+
+	section, err := s.sectionRepo.GetByKey(ctx, models.SectionKey(name))
+	if err == nil {
+		return section, nil
+	}
+
+	section, err = s.CreateSection(ctx, models.CreateSectionRequest{Name: name})
+	if err == ErrSectionExists {
+		// Created concurrently by another request
+		return s.sectionRepo.GetByKey(ctx, models.SectionKey(name))
+	}
+	return section, err
+}
+
+// Questions returns the question bank in section order, then question order.
+// Questions of inactive sections are left out unless includeInactive is set.
+func (s *SectionService) Questions(ctx context.Context, includeInactive bool) ([]models.Question, error) {
+	questions, err := s.questionRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.OrderQuestions(ctx, questions, includeInactive)
+}
+
+// OrderQuestions sorts questions by section display order, then by their order within the
+// section. Questions without a known section come last.
+func (s *SectionService) OrderQuestions(ctx context.Context, questions []models.Question, includeInactive bool) ([]models.Question, error) {
+	sections, err := s.sectionRepo.GetAllOrdered(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sectionRank := make(map[primitive.ObjectID]int, len(sections))
+	activeSections := make(map[primitive.ObjectID]bool, len(sections))
+	for rank, section := range sections {
+		sectionRank[section.ID] = rank
+		activeSections[section.ID] = section.Active
+	}
+
+	ordered := make([]models.Question, 0, len(questions))
+	for _, question := range questions {
+		if _, known := sectionRank[question.SectionID]; known && !activeSections[question.SectionID] && !includeInactive {
+			continue
+		}
+		ordered = append(ordered, question)
+	}
+
+	rankOf := func(question models.Question) int {
+		if rank, known := sectionRank[question.SectionID]; known {
+			return rank
+		}
+		return len(sections)
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		rankI, rankJ := rankOf(ordered[i]), rankOf(ordered[j])
+		if rankI != rankJ {
+			return rankI < rankJ
+		}
+		return ordered[i].Order < ordered[j].Order
+	})
+
+	return ordered, nil
+}
+
+// MigrateSections links questions that only carry a section name to a section, creating
+// sections from the distinct names. Questions keep their current order within each section.
+func (s *SectionService) MigrateSections(ctx context.Context) error {
+	questions, err := s.questionRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	nextOrder := make(map[primitive.ObjectID]int)
+	for _, question := range questions {
+		if !question.SectionID.IsZero() && question.Order >= nextOrder[question.SectionID] {
+			nextOrder[question.SectionID] = question.Order + 1
+		}
+	}
+
+	migrated := 0
+	for _, question := range questions {
+		if !question.SectionID.IsZero() {
+			continue
+		}
+
+		section, err := s.ResolveSection(ctx, question.Section)
+		if err != nil {
+			return err
+		}
+		if err := s.questionRepo.SetSection(ctx, question.ID, section, nextOrder[section.ID]); err != nil {
+			return err
+		}
+		nextOrder[section.ID]++
+		migrated++
+	}
+
+	if migrated > 0 {
+		log.Printf("Linked %d questions to sections", migrated)
+	}
+	return nil
+}
+
+// SectionWeights lists the sections that set a scoring weight, in display order
+func (s *SectionService) SectionWeights(ctx context.Context) ([]models.SectionWeight, error) {
+	sections, err := s.sectionRepo.GetAllOrdered(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sectionWeights := []models.SectionWeight{}
+	for _, section := range sections {
+		if section.Weight != nil {
+			sectionWeights = append(sectionWeights, sectionWeight(section))
+		}
+	}
+	return sectionWeights, nil
+}
+
+// SetSectionWeight sets the scoring weight of the section given by ID or name
+func (s *SectionService) SetSectionWeight(ctx context.Context, ref string, weight float64) (models.SectionWeight, error) {
+	section, err := s.findSection(ctx, ref)
+	if err != nil {
+		return models.SectionWeight{}, err
+	}
+
+	if err := s.sectionRepo.SetWeight(ctx, section.ID, &weight); err != nil {
+		return models.SectionWeight{}, err
+	}
+	section.Weight = &weight
+	return sectionWeight(section), nil
+}
+
+// ResetSectionWeight clears the scoring weight of the section given by ID or name, so its
+// questions fall back to the default weight
+func (s *SectionService) ResetSectionWeight(ctx context.Context, ref string) error {
+	section, err := s.findSection(ctx, ref)
+	if err != nil {
+		return err
+	}
+	if section.Weight == nil {
+		return ErrSectionWeightNotFound
+	}
+
+	return s.sectionRepo.SetWeight(ctx, section.ID, nil)
+}
+
+// MigrateSectionWeights moves the section weights kept by name onto their sections. Weights
+// of sections that no longer exist are dropped.
+func (s *SectionService) MigrateSectionWeights(ctx context.Context) error {
+	legacyWeights, err := s.sectionWeightRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	migrated := 0
+	for _, legacyWeight := range legacyWeights {
+		section, err := s.sectionRepo.GetByKey(ctx, models.SectionKey(legacyWeight.Section))
+		if err == nil && section.Weight == nil && models.IsValidWeight(legacyWeight.Weight) {
+			weight := legacyWeight.Weight
+			if err := s.sectionRepo.SetWeight(ctx, section.ID, &weight); err != nil {
+				return err
+			}
+			migrated++
+		}
+		if err := s.sectionWeightRepo.Delete(ctx, legacyWeight.ID.Hex()); err != nil {
+			return err
+		}
+	}
+
+	if migrated > 0 {
+		log.Printf("Moved %d section weights onto their sections", migrated)
+	}
+	return nil
+}
+
+// findSection resolves a section by ID, or by name when ref is not the ID of a section
+func (s *SectionService) findSection(ctx context.Context, ref string) (models.Section, error) {
+	if _, err := primitive.ObjectIDFromHex(ref); err == nil {
+		if section, err := s.sectionRepo.GetByID(ctx, ref); err == nil {
+			return section, nil
+		}
+	}
+
+	key := models.SectionKey(ref)
+	if key == "" {
+		return models.Section{}, ErrSectionNotFound
+	}
+	section, err := s.sectionRepo.GetByKey(ctx, key)
+	if err != nil {
+		return models.Section{}, ErrSectionNotFound
+	}
+	return section, nil
+}
+
+// sectionWeight returns the weight entry of a section that sets one
+func sectionWeight(section models.Section) models.SectionWeight {
+	return models.SectionWeight{ID: section.ID, Section: section.Name, Weight: *section.Weight}
+}
+
+// nextDisplayOrder returns the display order that places a new section last
+func (s *SectionService) nextDisplayOrder(ctx context.Context) (int, error) {
+	sections, err := s.sectionRepo.GetAllOrdered(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(sections) == 0 {
+		return 0, nil
+	}
+	return sections[len(sections)-1].DisplayOrder + 1, nil
+}
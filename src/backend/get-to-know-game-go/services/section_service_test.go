@@ -0,0 +1,76 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"get-to-know-game-go/models"
+)
+
+func TestApplySectionUpdateKeepsOmittedFields(t *testing.T) {
+	weight := 2.0
+	section := models.Section{
+		Key:          "food",
+		Name:         "Food",
+		Description:  "Things to eat",
+		Icon:         "🍕",
+		DisplayOrder: 3,
+		Active:       true,
+		Translations: map[string]models.SectionTranslation{"ro": {Name: "Mâncare"}},
+		Weight:       &weight,
+	}
+	order, inactive, empty := 7, false, ""
+
+	tests := []struct {
+		name string
+		req  models.UpdateSectionRequest
+		want func(section *models.Section)
+	}{
+		{
+			name: "name only",
+			req:  models.UpdateSectionRequest{Name: "Food"},
+			want: func(section *models.Section) {},
+		},
+		{
+			name: "display order only",
+			req:  models.UpdateSectionRequest{Name: "Food", DisplayOrder: &order},
+			want: func(section *models.Section) { section.DisplayOrder = 7 },
+		},
+		{
+			name: "active only",
+			req:  models.UpdateSectionRequest{Name: "Food", Active: &inactive},
+			want: func(section *models.Section) { section.Active = false },
+		},
+		{
+			name: "cleared description",
+			req:  models.UpdateSectionRequest{Name: "Food", Description: &empty},
+			want: func(section *models.Section) { section.Description = "" },
+		},
+		{
+			name: "renamed",
+			req:  models.UpdateSectionRequest{Name: "  Food   and drink "},
+			want: func(section *models.Section) { section.Key, section.Name = "food and drink", "Food and drink" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := section
+			tt.want(&want)
+
+			got, err := applySectionUpdate(section, tt.req)
+			if err != nil {
+				t.Fatalf("applySectionUpdate: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestApplySectionUpdateRequiresName(t *testing.T) {
+	if _, err := applySectionUpdate(models.Section{Name: "Food"}, models.UpdateSectionRequest{Name: "  "}); err != ErrSectionNameRequired {
+		t.Errorf("err = %v, want %v", err, ErrSectionNameRequired)
+	}
+}
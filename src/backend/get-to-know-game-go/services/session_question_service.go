@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"get-to-know-game-go/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// MaxCustomQuestions is how many questions of their own player 1 can add to a session
+	MaxCustomQuestions = 5
+	// defaultCustomSection is the section of custom questions submitted without one
+	defaultCustomSection = "Just Us"
+)
+
+var (
+	// ErrInvalidShuffleMode is returned when a session is created with an unknown shuffle mode
+	ErrInvalidShuffleMode = errors.New("invalid shuffle mode")
+	// ErrTooManyCustomQuestions is returned when player 1 writes more than MaxCustomQuestions questions
+	ErrTooManyCustomQuestions = errors.New("too many custom questions")
+	// ErrInvalidResponseType is returned for a custom question on an unknown answer scale
+	ErrInvalidResponseType = errors.New("invalid response type")
+)
+
+// SessionQuestionService resolves the set of questions played in a session
+type SessionQuestionService struct {
+	packService      *PackService
+	audienceService  *AudienceService
+	sectionService   *SectionService
+	contentValidator *ContentValidator
+}
+
+// NewSessionQuestionService creates a new session question service
+func NewSessionQuestionService(packService *PackService, audienceService *AudienceService, sectionService *SectionService, contentValidator *ContentValidator) *SessionQuestionService {
+	return &SessionQuestionService{
+		packService:      packService,
+		audienceService:  audienceService,
+		sectionService:   sectionService,
+		contentValidator: contentValidator,
+	}
+}
+
+// QuestionFilter validates the audience and tags a new session is restricted to
+func (s *SessionQuestionService) QuestionFilter(audience string, tags []string) (models.QuestionFilter, error) {
+	return s.audienceService.Filter(audience, tags)
+}
+
+// PinPack returns the published pack a new session plays, by ID or key with empty meaning the
+// default pack, making sure filter leaves questions of it to play
+func (s *SessionQuestionService) PinPack(ctx context.Context, ref string, filter models.QuestionFilter) (models.QuestionPack, error) {
+	pack, err := s.packService.Pin(ctx, ref)
+	if err != nil {
+		return models.QuestionPack{}, err
+	}
+
+	questions, err := s.packService.VersionQuestions(ctx, pack.ID, pack.PublishedVersion)
+	if err != nil {
+		return models.QuestionPack{}, err
+	}
+	if len(s.audienceService.Apply(questions, filter)) == 0 {
+		return models.QuestionPack{}, ErrNoMatchingQuestions
+	}
+	return pack, nil
+}
+
+// BuildCustomQuestions validates questions written by player 1 and gives them IDs.
+// The questions are only stored on the session, never in the questions collection.
+func (s *SessionQuestionService) BuildCustomQuestions(requests []models.CustomQuestionRequest) ([]models.Question, error) {
+	if len(requests) > MaxCustomQuestions {
+		return nil, ErrTooManyCustomQuestions
+	}
+
+	questions := make([]models.Question, 0, len(requests))
+	for _, request := range requests {
+		section := strings.TrimSpace(request.Section)
+		if section == "" {
+			section = defaultCustomSection
+		}
+		questionText := strings.TrimSpace(request.QuestionText)
+		if err := s.contentValidator.ValidateQuestion(section, questionText); err != nil {
+			return nil, err
+		}
+		if request.ResponseType != "" && !models.IsValidAnswerScale(request.ResponseType) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidResponseType, request.ResponseType)
+		}
+		questions = append(questions, models.Question{
+			ID:           primitive.NewObjectID(),
+			Section:      section,
+			QuestionText: questionText,
+			ResponseType: request.ResponseType,
+		})
+	}
+
+	return questions, nil
+}
+
+// Questions returns the questions of the pack version the session is pinned to in section order,
+// narrowed to the session's audience and tags, followed by the session's own custom questions
+func (s *SessionQuestionService) Questions(ctx context.Context, session models.GameSession) ([]models.Question, error) {
+	var questions []models.Question
+	var err error
+	if session.PackID.IsZero() {
+		questions, err = s.packService.DefaultDraftQuestions(ctx)
+	} else {
+		questions, err = s.packService.VersionQuestions(ctx, session.PackID, session.PackVersion)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	questions = s.audienceService.Apply(questions, session.QuestionFilter())
+	return append(questions, session.CustomQuestions...), nil
+}
+
+// NewShuffle validates the shuffle mode of a new session, defaulting to shuffling within sections,
+// and draws the seed its question order derives from
+func (s *SessionQuestionService) NewShuffle(mode string) (string, int64, error) {
+	if mode == "" {
+		mode = models.ShuffleSections
+	}
+	if !models.IsValidShuffleMode(mode) {
+		return "", 0, ErrInvalidShuffleMode
+	}
+
+	seed, err := newShuffleSeed()
+	if err != nil {
+		return "", 0, err
+	}
+	return mode, seed, nil
+}
+
+// Shuffled returns questions in the session's shuffled order. Each question is ranked by a hash of
+// the session's seed and its ID, so both players get the same order on every request, and the
+// remaining questions keep their relative order if one is removed from the set.
+func (s *SessionQuestionService) Shuffled(session models.GameSession, questions []models.Question) []models.Question {
+	if session.Shuffle == "" || session.Shuffle == models.ShuffleNone {
+		return questions
+	}
+
+	ranks := make(map[primitive.ObjectID]uint64, len(questions))
+	sectionOrder := make(map[string]int)
+	for _, question := range questions {
+		ranks[question.ID] = shuffleRank(session.ShuffleSeed, question.ID)
+		if _, exists := sectionOrder[models.SectionKey(question.Section)]; !exists {
+			sectionOrder[models.SectionKey(question.Section)] = len(sectionOrder)
+		}
+	}
+
+	shuffled := append([]models.Question(nil), questions...)
+	sort.SliceStable(shuffled, func(i, j int) bool {
+		if session.Shuffle == models.ShuffleSections {
+			sectionI := sectionOrder[models.SectionKey(shuffled[i].Section)]
+			sectionJ := sectionOrder[models.SectionKey(shuffled[j].Section)]
+			if sectionI != sectionJ {
+				return sectionI < sectionJ
+			}
+		}
+		return ranks[shuffled[i].ID] < ranks[shuffled[j].ID]
+	})
+	return shuffled
+}
+
+// Weights returns the effective weight of each question: its own weight times its section's weight.
+// Custom questions, which carry no section ID, take the weight of the section they name.
+func (s *SessionQuestionService) Weights(ctx context.Context, questions []models.Question) (map[string]float64, error) {
+	sections, err := s.sectionService.ListSections(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	weightByID := make(map[primitive.ObjectID]float64)
+	weightByKey := make(map[string]float64)
+	for _, section := range sections {
+		if section.Weight != nil {
+			weightByID[section.ID] = *section.Weight
+			weightByKey[section.Key] = *section.Weight
+		}
+	}
+
+	weights := make(map[string]float64, len(questions))
+	for _, question := range questions {
+		weight := models.DefaultWeight
+		if question.Weight != nil {
+			weight = *question.Weight
+		}
+		if question.SectionID.IsZero() {
+			if sectionWeight, exists := weightByKey[models.SectionKey(question.Section)]; exists {
+				weight *= sectionWeight
+			}
+		} else if sectionWeight, exists := weightByID[question.SectionID]; exists {
+			weight *= sectionWeight
+		}
+		weights[question.ID.Hex()] = weight
+	}
+
+	return weights, nil
+}
+
+// QuestionSetKey identifies the bank questions a session is played with. Custom questions are
+// left out since they are unique to the session, so sessions on the same bank share a key.
+func (s *SessionQuestionService) QuestionSetKey(session models.GameSession, questions []models.Question) string {
+	customIDs := make(map[primitive.ObjectID]bool, len(session.CustomQuestions))
+	for _, question := range session.CustomQuestions {
+		customIDs[question.ID] = true
+	}
+
+	ids := make([]string, 0, len(questions))
+	for _, question := range questions {
+		if !customIDs[question.ID] {
+			ids = append(ids, question.ID.Hex())
+		}
+	}
+	sort.Strings(ids)
+
+	hash := sha256.Sum256([]byte(strings.Join(ids, ",")))
+	return "set-" + hex.EncodeToString(hash[:8])
+}
+
+// ValidateAnswers checks that answers contain exactly one response per session question, valid on
+// the question's scale. In prediction mode every answer must also carry a guess on the same scale.
+func (s *SessionQuestionService) ValidateAnswers(ctx context.Context, session models.GameSession, answers []models.PlayerAnswer) error {
+	questions, err := s.Questions(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	return validateAnswerSet(questions, answers, session.GameMode() == models.GameModePrediction)
+}
+
+// newShuffleSeed draws a random seed for a session's question order
+func newShuffleSeed() (int64, error) {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(buf[:])), nil
+}
+
+// shuffleRank places a question in the shuffled order of the session with the given seed
+func shuffleRank(seed int64, id primitive.ObjectID) uint64 {
+	var buf [8 + len(id)]byte
+	binary.BigEndian.PutUint64(buf[:8], uint64(seed))
+	copy(buf[8:], id[:])
+	hash := sha256.Sum256(buf[:])
+	return binary.BigEndian.Uint64(hash[:8])
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"get-to-know-game-go/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// shuffleQuestions returns 18 questions with fixed IDs, six in each of three sections
+func shuffleQuestions(t *testing.T) []models.Question {
+	t.Helper()
+	sections := []string{"Food", "Travel", "Music"}
+	questions := make([]models.Question, 0, 18)
+	for i := 0; i < 18; i++ {
+		id, err := primitive.ObjectIDFromHex(fmt.Sprintf("%024x", i+1))
+		if err != nil {
+			t.Fatalf("question ID: %v", err)
+		}
+		questions = append(questions, models.Question{ID: id, Section: sections[i/6], Order: i % 6})
+	}
+	return questions
+}
+
+// questionIDs returns the hex IDs of questions in order
+func questionIDs(questions []models.Question) []string {
+	ids := make([]string, len(questions))
+	for i, question := range questions {
+		ids[i] = question.ID.Hex()
+	}
+	return ids
+}
+
+// sectionRuns returns the sections of questions in order, each run of one section listed once
+func sectionRuns(questions []models.Question) []string {
+	var runs []string
+	for _, question := range questions {
+		if len(runs) == 0 || runs[len(runs)-1] != question.Section {
+			runs = append(runs, question.Section)
+		}
+	}
+	return runs
+}
+
+func TestShuffled(t *testing.T) {
+	service := &SessionQuestionService{}
+	questions := shuffleQuestions(t)
+
+	tests := []struct {
+		name string
+		mode string
+		// reorders reports whether the mode changes the order, so different seeds give different orders
+		reorders bool
+		// keepsSections reports whether each section's questions stay together in the original section order
+		keepsSections bool
+	}{
+		{name: "unset", mode: "", keepsSections: true},
+		{name: "none", mode: models.ShuffleNone, keepsSections: true},
+		{name: "sections", mode: models.ShuffleSections, reorders: true, keepsSections: true},
+		{name: "all", mode: models.ShuffleAll, reorders: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := models.GameSession{Shuffle: tt.mode, ShuffleSeed: 42}
+			got := service.Shuffled(session, questions)
+
+			again := service.Shuffled(session, questions)
+			if !reflect.DeepEqual(questionIDs(got), questionIDs(again)) {
+				t.Errorf("order changed between calls with the same seed")
+			}
+
+			gotIDs, wantIDs := questionIDs(got), questionIDs(questions)
+			sort.Strings(gotIDs)
+			sort.Strings(wantIDs)
+			if !reflect.DeepEqual(gotIDs, wantIDs) {
+				t.Errorf("shuffled questions %v are not a permutation of %v", gotIDs, wantIDs)
+			}
+
+			other := service.Shuffled(models.GameSession{Shuffle: tt.mode, ShuffleSeed: 7}, questions)
+			sameOrder := reflect.DeepEqual(questionIDs(got), questionIDs(other))
+			if tt.reorders && sameOrder {
+				t.Errorf("seeds 42 and 7 gave the same order")
+			}
+			if !tt.reorders && !reflect.DeepEqual(questionIDs(got), questionIDs(questions)) {
+				t.Errorf("order changed without shuffling")
+			}
+
+			if tt.keepsSections && !reflect.DeepEqual(sectionRuns(got), []string{"Food", "Travel", "Music"}) {
+				t.Errorf("sections ran %v, want Food, Travel, Music", sectionRuns(got))
+			}
+		})
+	}
+}
+
+func TestShuffledKeepsOrderWhenAQuestionIsRemoved(t *testing.T) {
+	service := &SessionQuestionService{}
+	questions := shuffleQuestions(t)
+	session := models.GameSession{Shuffle: models.ShuffleAll, ShuffleSeed: 42}
+
+	full := service.Shuffled(session, questions)
+	removed := full[5].ID
+	var want []string
+	for _, question := range full {
+		if question.ID != removed {
+			want = append(want, question.ID.Hex())
+		}
+	}
+
+	var remaining []models.Question
+	for _, question := range questions {
+		if question.ID != removed {
+			remaining = append(remaining, question)
+		}
+	}
+	if got := questionIDs(service.Shuffled(session, remaining)); !reflect.DeepEqual(got, want) {
+		t.Errorf("order after removing a question = %v, want %v", got, want)
+	}
+}
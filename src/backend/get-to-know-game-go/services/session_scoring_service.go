@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"get-to-know-game-go/models"
+	"get-to-know-game-go/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrSessionScored is returned when answers are submitted to a session that has already been scored
+var ErrSessionScored = errors.New("answers can't change once the session is scored")
+
+// SessionScoringService calculates and stores compatibility once both players of a session have answered
+type SessionScoringService struct {
+	sessionRepo            repositories.GameSessionRepository
+	sessionQuestionService *SessionQuestionService
+	compatibilityService   *CompatibilityService
+	distributionService    *ScoreDistributionService
+	frequencyService       *AnswerFrequencyService
+	scorerName             string
+	dealbreakerCap         int
+}
+
+// NewSessionScoringService creates a new session scoring service storing scores from the named strategy.
+// Sessions with a triggered dealbreaker score at most dealbreakerCap.
+func NewSessionScoringService(
+	sessionRepo repositories.GameSessionRepository,
+	sessionQuestionService *SessionQuestionService,
+	compatibilityService *CompatibilityService,
+	distributionService *ScoreDistributionService,
+	frequencyService *AnswerFrequencyService,
+	scorerName string,
+	dealbreakerCap int,
+) *SessionScoringService {
+	return &SessionScoringService{
+		sessionRepo:            sessionRepo,
+		sessionQuestionService: sessionQuestionService,
+		compatibilityService:   compatibilityService,
+		distributionService:    distributionService,
+		frequencyService:       frequencyService,
+		scorerName:             scorerName,
+		dealbreakerCap:         dealbreakerCap,
+	}
+}
+
+// ScoreIfComplete scores the session if both players have submitted answers, whichever finished last.
+// The question weights in effect are stored with the score so later re-weighting leaves it unchanged.
+// It reports whether this call scored the session; a session that already has a score is left as it is.
+func (s *SessionScoringService) ScoreIfComplete(ctx context.Context, sessionID string) (bool, error) {
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return false, err
+	}
+
+	if len(session.Player1Answers) == 0 || session.Player2ID == nil || session.Player2Answers == nil || len(*session.Player2Answers) == 0 {
+		return false, nil
+	}
+
+	questions, err := s.sessionQuestionService.Questions(ctx, session)
+	if err != nil {
+		return false, err
+	}
+
+	input, err := s.scoreInput(ctx, questions)
+	if err != nil {
+		return false, err
+	}
+	input.Player1Answers, input.Player2Answers = session.Player1Answers, *session.Player2Answers
+
+	result, err := s.scorePair(input, session.Player1ID, *session.Player2ID)
+	if err != nil {
+		return false, err
+	}
+
+	sessionScore := models.SessionScore{
+		CompatibilityScore: result.score,
+		ScoringStrategy:    result.scorer.Name(),
+		ScoringVersion:     result.scorer.Version(),
+		QuestionWeights:    input.Weights,
+		SectionScores:      s.compatibilityService.CalculateSectionScores(session.Player1Answers, *session.Player2Answers, questions),
+		Dealbreakers:       result.dealbreakers,
+		ScoreCapped:        result.capped,
+		QuestionSetKey:     s.sessionQuestionService.QuestionSetKey(session, questions),
+	}
+	// When both players finish at once both requests get here, but only one stores the score
+	scored, err := s.sessionRepo.UpdateCompatibilityScore(ctx, sessionID, sessionScore)
+	if err != nil || !scored {
+		return false, err
+	}
+
+	// The score is already stored; a failed statistics update only skews percentiles.
+	// Only the request that stored the score counts the session, so it is counted once.
+	if err := s.distributionService.Record(ctx, session, sessionScore.QuestionSetKey, result.score); err != nil {
+		log.Printf("Failed to record score distribution for session %s: %v", sessionID, err)
+	}
+	if err := s.frequencyService.Record(ctx, session); err != nil {
+		log.Printf("Failed to record answer frequencies for session %s: %v", sessionID, err)
+	}
+
+	return true, nil
+}
+
+// pairScore is the compatibility of two players with the dealbreakers they triggered
+type pairScore struct {
+	score        int
+	scorer       Scorer
+	dealbreakers []models.TriggeredDealbreaker
+	capped       bool
+}
+
+// scoreInput returns the question weights and answer frequencies for scoring answers to questions,
+// without the answers themselves
+func (s *SessionScoringService) scoreInput(ctx context.Context, questions []models.Question) (ScoreInput, error) {
+	weights, err := s.sessionQuestionService.Weights(ctx, questions)
+	if err != nil {
+		return ScoreInput{}, err
+	}
+
+	frequencies, err := s.frequencyService.Frequencies(ctx, questions)
+	if err != nil {
+		return ScoreInput{}, err
+	}
+
+	return ScoreInput{Weights: weights, Frequencies: frequencies}, nil
+}
+
+// scorePair scores two players with the configured strategy and caps the score at dealbreakerCap
+// when either of them has a dealbreaker the other contradicts
+func (s *SessionScoringService) scorePair(input ScoreInput, player1ID, player2ID primitive.ObjectID) (pairScore, error) {
+	score, scorer, err := s.compatibilityService.ScoreWith(s.scorerName, input)
+	if err != nil {
+		return pairScore{}, err
+	}
+
+	dealbreakers := s.compatibilityService.FindDealbreakers(player1ID, player2ID, input.Player1Answers, input.Player2Answers)
+	capped := len(dealbreakers) > 0 && score > s.dealbreakerCap
+	if capped {
+		score = s.dealbreakerCap
+	}
+
+	return pairScore{score: score, scorer: scorer, dealbreakers: dealbreakers, capped: capped}, nil
+}
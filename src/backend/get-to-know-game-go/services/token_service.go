@@ -0,0 +1,46 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// tokenBytes is the amount of randomness in an access token
+const tokenBytes = 32
+
+// TokenService issues and verifies secret access tokens.
+// Only hashes of tokens are ever stored.
+type TokenService struct{}
+
+// NewTokenService creates a new token service
+func NewTokenService() *TokenService {
+	return &TokenService{}
+}
+
+// GenerateToken returns a new unguessable token together with its hash
+func (s *TokenService) GenerateToken() (string, string, error) {
+	buf := make([]byte, tokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, s.HashToken(token), nil
+}
+
+// HashToken returns the hex encoded SHA-256 hash of a token
+func (s *TokenService) HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Matches reports whether token hashes to the stored hash
+func (s *TokenService) Matches(hash, token string) bool {
+	if hash == "" || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(s.HashToken(token))) == 1
+}
@@ -9,11 +9,11 @@ class ApiService {
         console.log('API Request:', url, options.method || 'GET');
         
         const config = {
+            ...options,
             headers: {
                 'Content-Type': 'application/json',
                 ...options.headers,
             },
-            ...options,
         };
 
         try {
@@ -30,26 +30,28 @@ class ApiService {
         }
     }
 
-    async get(endpoint) {
-        return this.request(endpoint, { method: 'GET' });
+    async get(endpoint, headers = {}) {
+        return this.request(endpoint, { method: 'GET', headers });
     }
 
-    async post(endpoint, data) {
+    async post(endpoint, data, headers = {}) {
         return this.request(endpoint, {
             method: 'POST',
             body: JSON.stringify(data),
+            headers,
         });
     }
 
-    async put(endpoint, data) {
+    async put(endpoint, data, headers = {}) {
         return this.request(endpoint, {
             method: 'PUT',
             body: JSON.stringify(data),
+            headers,
         });
     }
 
-    async delete(endpoint) {
-        return this.request(endpoint, { method: 'DELETE' });
+    async delete(endpoint, headers = {}) {
+        return this.request(endpoint, { method: 'DELETE', headers });
     }
 }
 
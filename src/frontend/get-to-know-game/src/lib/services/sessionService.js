@@ -1,31 +1,60 @@
 import { apiService } from './api.js';
 
+// Each player's access token is kept per session in local storage and sent
+// as a bearer token on every session request that needs it.
+function tokenKey(sessionId) {
+    return `session-token:${sessionId}`;
+}
+
+function saveToken(sessionId, token) {
+    if (token && typeof localStorage !== 'undefined') {
+        localStorage.setItem(tokenKey(sessionId), token);
+    }
+}
+
+function authHeaders(sessionId) {
+    const token = typeof localStorage !== 'undefined' ? localStorage.getItem(tokenKey(sessionId)) : null;
+    return token ? { Authorization: `Bearer ${token}` } : {};
+}
+
 export const sessionService = {
     async createSession(player1Name, player2Name) {
-        return apiService.post('/sessions', {
+        const result = await apiService.post('/sessions', {
             player1Name,
             player2Name
         });
+        saveToken(result.sessionId, result.player1Token);
+        return result;
     },
 
     async getSession(sessionId) {
-        return apiService.get(`/sessions/${sessionId}`);
+        return apiService.get(`/sessions/${sessionId}`, authHeaders(sessionId));
+    },
+
+    async getQuestions(sessionId) {
+        return apiService.get(`/sessions/${sessionId}/questions`);
+    },
+
+    async getResults(sessionId) {
+        return apiService.get(`/sessions/${sessionId}/results`, authHeaders(sessionId));
     },
 
     async joinSession(sessionId, player2Name) {
-        return apiService.post(`/sessions/${sessionId}/join`, {
+        const result = await apiService.post(`/sessions/${sessionId}/join`, {
             player2Name
         });
+        saveToken(sessionId, result.player2Token);
+        return result;
     },
 
     async submitAnswers(sessionId, playerId, answers) {
         return apiService.put(`/sessions/${sessionId}/answers`, {
             playerId,
             answers
-        });
+        }, authHeaders(sessionId));
     },
 
     async deleteSession(sessionId) {
-        return apiService.delete(`/sessions/${sessionId}`);
+        return apiService.delete(`/sessions/${sessionId}`, authHeaders(sessionId));
     }
 };